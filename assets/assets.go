@@ -3,6 +3,8 @@ package assets
 import (
 	"embed"
 	"io"
+	"io/fs"
+	"path"
 
 	"github.com/quasilyte/ge"
 )
@@ -20,6 +22,28 @@ func MakeOpenAssetFunc(ctx *ge.Context) func(path string) io.ReadCloser {
 	}
 }
 
+// ReadDataFiles returns the contents of every file inside the given _data subdirectory.
+// Unlike the registered resources, these files don't need any Go code to be added.
+func ReadDataFiles(dir string) ([][]byte, error) {
+	root := path.Join("_data", dir)
+	entries, err := fs.ReadDir(gameAssets, root)
+	if err != nil {
+		return nil, err
+	}
+	files := make([][]byte, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		data, err := fs.ReadFile(gameAssets, path.Join(root, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, data)
+	}
+	return files, nil
+}
+
 func RegisterResources(ctx *ge.Context) {
 	registerImageResources(ctx)
 	registerSoundResources(ctx)
@@ -19,7 +19,10 @@ func registerImageResources(ctx *ge.Context) {
 		ImageUIPanelIdle:           {Path: "image/ebitenui/panel-idle.png"},
 
 		ImageSystemMap:     {Path: "image/map.png"},
+		ImageGalaxyMap:     {Path: "image/map_galaxy.png"},
 		ImageMapLocation:   {Path: "image/map_location.png"},
+		ImageMapPlanets:    {Path: "image/map_planets.png", FrameWidth: 32},
+		ImageMapStars:      {Path: "image/map_stars.png", FrameWidth: 16},
 		ImageAlliedPlanet:  {Path: "image/allied_planet_sector.png"},
 		ImageHostilePlanet: {Path: "image/hostile_planet_sector.png"},
 
@@ -57,6 +60,9 @@ func registerImageResources(ctx *ge.Context) {
 
 		ImageVesselPlayer:      {Path: "image/vessel/player.png", FrameWidth: 48},
 		ImageVesselPlayerElite: {Path: "image/vessel/player_elite.png", FrameWidth: 48},
+		ImageVesselCourier:     {Path: "image/vessel/courier.png", FrameWidth: 48},
+		ImageVesselHauler:      {Path: "image/vessel/hauler.png", FrameWidth: 48},
+		ImageVesselGunship:     {Path: "image/vessel/gunship.png", FrameWidth: 48},
 		ImageVesselBetaSmall:   {Path: "image/vessel/beta_small.png", FrameWidth: 48},
 		ImageVesselBetaBig:     {Path: "image/vessel/beta_big.png", FrameWidth: 48},
 		ImageVesselGammaSmall:  {Path: "image/vessel/gamma_small.png", FrameWidth: 48},
@@ -85,7 +91,10 @@ const (
 	ImageMenuBg
 
 	ImageSystemMap
+	ImageGalaxyMap
 	ImageMapLocation
+	ImageMapPlanets
+	ImageMapStars
 	ImageAlliedPlanet
 	ImageHostilePlanet
 
@@ -121,6 +130,9 @@ const (
 
 	ImageVesselPlayer
 	ImageVesselPlayerElite
+	ImageVesselCourier
+	ImageVesselHauler
+	ImageVesselGunship
 	ImageVesselBetaSmall
 	ImageVesselBetaBig
 	ImageVesselGammaSmall
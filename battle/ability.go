@@ -0,0 +1,28 @@
+package battle
+
+import (
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+type ability struct {
+	design *gamedata.BattleAbility
+
+	cooldown float64
+
+	// shieldTime is only used by the emergency shield ability.
+	shieldTime float64
+}
+
+func newAbility(design *gamedata.BattleAbility) *ability {
+	return &ability{
+		design: design,
+		// Abilities are not available right at the start of the battle.
+		cooldown: design.Cooldown * 0.25,
+	}
+}
+
+func (a *ability) Tick(delta float64) {
+	a.cooldown = gmath.ClampMin(a.cooldown-delta, 0)
+	a.shieldTime = gmath.ClampMin(a.shieldTime-delta, 0)
+}
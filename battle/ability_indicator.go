@@ -0,0 +1,52 @@
+package battle
+
+import (
+	"fmt"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/assets"
+)
+
+// abilityIndicator is a battle HUD element that shows the vessel ability readiness.
+type abilityIndicator struct {
+	pos    gmath.Vec
+	label  *ge.Label
+	vessel *vesselNode
+	title  string
+}
+
+func newAbilityIndicator(pos gmath.Vec, title string, v *vesselNode) *abilityIndicator {
+	return &abilityIndicator{
+		pos:    pos,
+		title:  title,
+		vessel: v,
+	}
+}
+
+func (ind *abilityIndicator) Init(scene *ge.Scene) {
+	ind.label = ge.NewLabel(assets.BitmapFont1)
+	ind.label.Pos.Base = &ind.pos
+	ind.label.Width = 160
+	ind.label.Height = 40
+	scene.AddGraphicsAbove(ind.label, 1)
+	ind.updateText()
+}
+
+func (ind *abilityIndicator) IsDisposed() bool { return false }
+
+func (ind *abilityIndicator) Update(delta float64) {
+	ind.updateText()
+}
+
+func (ind *abilityIndicator) updateText() {
+	a := ind.vessel.state.ability
+	status := "READY"
+	switch {
+	case a.shieldTime > 0:
+		status = "ACTIVE"
+	case a.cooldown > 0:
+		status = fmt.Sprintf("%.1fs", a.cooldown)
+	}
+	ind.label.Text = fmt.Sprintf("%s\n%s: %s", ind.title, a.design.Name, status)
+}
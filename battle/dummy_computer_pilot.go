@@ -3,6 +3,7 @@ package battle
 import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
 )
 
 type dummyComputerPilot struct {
@@ -47,6 +48,31 @@ func (p *dummyComputerPilot) Update(delta float64) {
 
 	p.navigate(delta)
 	p.attack(delta)
+	p.useAbility()
+}
+
+func (p *dummyComputerPilot) useAbility() {
+	state := &p.vessel.state
+	if !state.CanUseAbility() {
+		return
+	}
+
+	enemyDist := p.vessel.body.Pos.DistanceTo(p.enemy.body.Pos)
+	use := false
+	switch state.ability.design.Kind {
+	case gamedata.AbilityEmergencyShield:
+		use = state.HealthPercentage() < 0.6 && enemyDist < 200
+	case gamedata.AbilityBlink:
+		// Blink away when the enemy is too close and is behind.
+		use = enemyDist < 90 && p.targetAngleDelta.Abs() > 2
+	case gamedata.AbilityEMP:
+		use = p.enemy.state.EnergyPercentage() > 0.5
+	case gamedata.AbilityRepairBurst:
+		use = state.HealthPercentage() < 0.4
+	}
+	if use {
+		p.vessel.ActivateAbilityOrder()
+	}
 }
 
 func (p *dummyComputerPilot) attack(delta float64) {
@@ -65,7 +91,7 @@ func (p *dummyComputerPilot) attack(delta float64) {
 			return
 		}
 
-		noAttackChance := (p.vessel.state.hp / p.vessel.state.design.MaxHP) * 0.3
+		noAttackChance := p.vessel.state.HealthPercentage() * 0.3
 		if enemyDist < 150 {
 			noAttackChance *= 0.3
 		}
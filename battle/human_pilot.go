@@ -28,6 +28,9 @@ func (p *humanPilot) Update(delta float64) {
 	if p.input.ActionIsPressed(controls.ActionFireSpecial) {
 		p.vessel.ActivateSpecialOrder()
 	}
+	if p.input.ActionIsJustPressed(controls.ActionAbility) {
+		p.vessel.ActivateAbilityOrder()
+	}
 	if p.input.ActionIsPressed(controls.ActionForward) {
 		p.vessel.ForwardOrder()
 	}
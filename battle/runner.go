@@ -8,6 +8,7 @@ import (
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/gsignal"
 	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/controls"
 	"github.com/quasilyte/vcgj7-game/gamedata"
 )
 
@@ -17,6 +18,7 @@ type Runner struct {
 	pilots []pilot
 
 	player *gamedata.Player
+	stats  *gamedata.RunStats
 
 	input *input.Handler
 
@@ -24,26 +26,40 @@ type Runner struct {
 	enemyVessel  *vesselNode
 
 	enemyDesign *gamedata.VesselDesign
+	enemyHP     float64
+
+	finished bool
 
 	EventBattleOver gsignal.Event[Results]
 }
 
 type Results struct {
 	Victory bool
+	Retreat bool
 	HP      float64
+	EnemyHP float64
+
+	// DamageTaken is a total damage the player vessel received during the battle.
+	DamageTaken float64
 }
 
 type RunnerConfig struct {
-	Input  *input.Handler
-	Player *gamedata.Player
-	Enemy  *gamedata.VesselDesign
+	Input   *input.Handler
+	Player  *gamedata.Player
+	Enemy   *gamedata.VesselDesign
+	EnemyHP float64
+
+	// Stats are updated during the battle if not nil.
+	Stats *gamedata.RunStats
 }
 
 func NewRunner(config RunnerConfig) *Runner {
 	return &Runner{
 		input:       config.Input,
 		enemyDesign: config.Enemy,
+		enemyHP:     config.EnemyHP,
 		player:      config.Player,
+		stats:       config.Stats,
 	}
 }
 
@@ -60,8 +76,12 @@ func (r *Runner) Init(scene *ge.Scene) {
 	scene.AddGraphicsBelow(bg, 1)
 
 	v := newVesselNode(vesselNodeConfig{
-		HP:     r.player.VesselHP,
-		Design: r.player.VesselDesign,
+		HP:               r.player.VesselHP,
+		Design:           r.player.VesselDesign,
+		ReloadMultiplier: r.player.ReloadMultiplier(),
+		Player:           r.player,
+		Ability:          r.player.BattleAbility(),
+		Stats:            r.stats,
 	})
 	v.body.Pos = (gmath.Vec{X: 1920 / 4, Y: 1080 / 4}).Sub(gmath.Vec{X: 240})
 	v.body.LayerMask = collisionPlayer1
@@ -76,7 +96,7 @@ func (r *Runner) Init(scene *ge.Scene) {
 
 	{
 		v2 := newVesselNode(vesselNodeConfig{
-			HP:     1,
+			HP:     r.enemyHP,
 			Design: r.enemyDesign,
 		})
 		v2.body.LayerMask = collisionPlayer2
@@ -94,38 +114,79 @@ func (r *Runner) Init(scene *ge.Scene) {
 		r.pilots = append(r.pilots, p)
 	}
 
+	{
+		info := gamedata.BattleStartInfo{
+			HP:      v.state.HealthPercentage(),
+			Energy:  v.state.EnergyPercentage(),
+			EnemyHP: r.enemyVessel.state.HealthPercentage(),
+		}
+		r.player.RunBattleStartHooks(scene.Rand(), &info)
+		v.state.hp = v.state.TotalMaxHP() * info.HP
+		v.state.energy = v.state.TotalMaxEnergy() * info.Energy
+		r.enemyVessel.state.hp = r.enemyVessel.state.TotalMaxHP() * info.EnemyHP
+	}
+
 	hud := scene.NewSprite(assets.ImageBattleHUD)
 	hud.Centered = false
 	scene.AddGraphicsAbove(hud, 1)
 
+	if v.state.ability != nil {
+		scene.AddObject(newAbilityIndicator(gmath.Vec{X: 16, Y: 480}, "[Space] ability", v))
+	}
+	if r.enemyVessel.state.ability != nil {
+		scene.AddObject(newAbilityIndicator(gmath.Vec{X: 790, Y: 480}, "Enemy ability", r.enemyVessel))
+	}
+
 	{
 		pos := gmath.Vec{X: 178, Y: 50}
-		hpBar := newValueBar(pos, &v.state.hp, v.state.design.MaxHP, true)
+		hpBar := newValueBar(pos, &v.state.hp, v.state.TotalMaxHP(), true)
 		scene.AddObject(hpBar)
 	}
 	{
 		pos := gmath.Vec{X: 178 + 494, Y: 50}
-		hpBar := newValueBar(pos, &v.state.energy, v.state.design.MaxEnergy, false)
+		hpBar := newValueBar(pos, &v.state.energy, v.state.TotalMaxEnergy(), false)
 		scene.AddObject(hpBar)
 	}
 }
 
 func (r *Runner) onDefeat(gsignal.Void) {
+	r.finished = true
 	r.enemyVessel.body.LayerMask = 0
 	r.EventBattleOver.Emit(Results{
-		Victory: false,
+		Victory:     false,
+		EnemyHP:     r.enemyVessel.state.HealthPercentage(),
+		DamageTaken: r.playerVessel.state.damageTaken,
 	})
 }
 
 func (r *Runner) onVictory(gsignal.Void) {
+	r.finished = true
 	r.playerVessel.body.LayerMask = 0
 	r.EventBattleOver.Emit(Results{
-		Victory: true,
-		HP:      r.playerVessel.state.HealthPercentage(),
+		Victory:     true,
+		HP:          r.playerVessel.state.HealthPercentage(),
+		DamageTaken: r.playerVessel.state.damageTaken,
+	})
+}
+
+func (r *Runner) retreat() {
+	r.finished = true
+	r.enemyVessel.body.LayerMask = 0
+	r.playerVessel.Dispose()
+	r.EventBattleOver.Emit(Results{
+		Retreat:     true,
+		HP:          r.playerVessel.state.HealthPercentage(),
+		EnemyHP:     r.enemyVessel.state.HealthPercentage(),
+		DamageTaken: r.playerVessel.state.damageTaken,
 	})
 }
 
 func (r *Runner) Update(delta float64) {
+	if !r.finished && r.input.ActionIsJustPressed(controls.ActionBack) && r.player.Fuel >= gamedata.RetreatFuelCost {
+		r.retreat()
+		return
+	}
+
 	for _, p := range r.pilots {
 		p.Update(delta)
 	}
@@ -12,6 +12,19 @@ import (
 type vesselNodeConfig struct {
 	HP     float64
 	Design *gamedata.VesselDesign
+
+	// ReloadMultiplier is 1.0 if not specified.
+	ReloadMultiplier float64
+
+	// Player is only set for the player-controlled vessel.
+	// It's used to run the artifact hooks.
+	Player *gamedata.Player
+
+	// Ability overrides the design ability if set.
+	Ability *gamedata.BattleAbility
+
+	// Stats are only set for the player-controlled vessel.
+	Stats *gamedata.RunStats
 }
 
 type vesselNode struct {
@@ -39,6 +52,7 @@ type vesselPilotOrders struct {
 	forward         bool
 	activateWeapon  bool
 	activateSpecial bool
+	activateAbility bool
 }
 
 func newVesselNode(config vesselNodeConfig) *vesselNode {
@@ -46,6 +60,10 @@ func newVesselNode(config vesselNodeConfig) *vesselNode {
 		config: config,
 	}
 	v.state.design = config.Design
+	v.state.reloadMultiplier = config.ReloadMultiplier
+	if v.state.reloadMultiplier == 0 {
+		v.state.reloadMultiplier = 1
+	}
 	v.state.Pos = &v.body.Pos
 	v.state.Rotation = &v.body.Rotation
 	return v
@@ -61,7 +79,10 @@ func (v *vesselNode) Init(scene *ge.Scene) {
 	v.scene = scene
 
 	state.Init()
-	v.state.hp = v.state.design.MaxHP * v.config.HP
+	if v.config.Ability != nil {
+		state.ability = newAbility(v.config.Ability)
+	}
+	v.state.hp = v.state.TotalMaxHP() * v.config.HP
 	v.state.Pos = &v.body.Pos
 	v.state.Rotation = &v.body.Rotation
 
@@ -116,6 +137,10 @@ func (v *vesselNode) ActivateWeaponOrder() {
 	v.pilotOrders.activateWeapon = true
 }
 
+func (v *vesselNode) ActivateAbilityOrder() {
+	v.pilotOrders.activateAbility = true
+}
+
 func (v *vesselNode) OnDamage(weapon *gamedata.WeaponDesign, consumed bool) {
 	// scorePos := ge.Pos{Offset: v.body.Pos.Add(gmath.Vec{Y: -48})}
 	// score := effects.NewDamageScore(value, scorePos)
@@ -124,11 +149,28 @@ func (v *vesselNode) OnDamage(weapon *gamedata.WeaponDesign, consumed bool) {
 	if v.state.hp <= 0 {
 		return
 	}
+	if v.state.IsInvulnerable() {
+		return
+	}
 
 	damage := weapon.Damage
 	if consumed {
-		damage *= 0.25
+		damage *= v.state.TotalShieldDamageMultiplier()
 	}
+	if v.config.Player != nil {
+		info := gamedata.DamageInfo{Damage: damage, Blocked: consumed}
+		v.config.Player.RunDamageHooks(v.scene.Rand(), &info)
+		damage = info.Damage
+	}
+	if v.config.Stats != nil {
+		v.config.Stats.AddDamageTaken(weapon, damage)
+	}
+	if stats := v.state.enemy.config.Stats; stats != nil {
+		ws := stats.WeaponStats(weapon)
+		ws.Hits++
+		ws.DamageDealt += gmath.ClampMax(damage, v.state.hp)
+	}
+	v.state.damageTaken += damage
 	v.state.hp = gmath.ClampMin(v.state.hp-damage, 0)
 	if v.state.hp <= 0 {
 		v.Destroy()
@@ -150,7 +192,7 @@ func (v *vesselNode) Update(delta float64) {
 			obj.Destroy(!consumed)
 			if consumed {
 				energyGain := obj.weapon.EnergyCost * obj.weapon.EnergyConversion
-				v.state.energy = gmath.ClampMax(v.state.energy+energyGain, v.state.design.MaxEnergy)
+				v.state.energy = gmath.ClampMax(v.state.energy+energyGain, v.state.TotalMaxEnergy())
 				playSound(v.scene, assets.AudioShieldAbsorb)
 				v.OnDamage(obj.weapon, true)
 			} else {
@@ -182,6 +224,11 @@ func (v *vesselNode) Update(delta float64) {
 			v.createProjectiles(v.state.design.SecondaryWeapon)
 		}
 	}
+	if pilotOrders.activateAbility {
+		if v.state.CanUseAbility() {
+			v.useAbility()
+		}
+	}
 
 	// if state.specialWeapon != nil {
 	// 	state.specialWeapon.Update(delta)
@@ -205,8 +252,38 @@ func (v *vesselNode) Update(delta float64) {
 	v.wrap.Tick(delta, &v.body.Pos)
 }
 
+func (v *vesselNode) useAbility() {
+	a := v.state.ability
+	a.cooldown = a.design.Cooldown
+
+	switch a.design.Kind {
+	case gamedata.AbilityEmergencyShield:
+		a.shieldTime = a.design.Power
+		playSound(v.scene, assets.AudioShieldAbsorb)
+
+	case gamedata.AbilityBlink:
+		e := newEffectNode(v.body.Pos, normalEffectLayer, assets.ImageBigExplosion)
+		v.scene.AddObject(e)
+		e.anim.SetSecondsPerFrame(0.03)
+		v.body.Pos = v.body.Pos.Add(gmath.RadToVec(v.body.Rotation).Mulf(a.design.Power))
+
+	case gamedata.AbilityEMP:
+		enemy := &v.state.enemy.state
+		enemy.energy = gmath.ClampMin(enemy.energy-a.design.Power, 0)
+		e := newEffectNode(v.state.enemy.body.Pos, aboveEffectLayer, assets.ImageBigExplosion)
+		v.scene.AddObject(e)
+		e.anim.SetSecondsPerFrame(0.03)
+
+	case gamedata.AbilityRepairBurst:
+		v.state.hp = gmath.ClampMax(v.state.hp+v.state.TotalMaxHP()*a.design.Power, v.state.TotalMaxHP())
+	}
+}
+
 func (v *vesselNode) createProjectiles(weapon *gamedata.WeaponDesign) {
 	playSound(v.scene, weapon.FireSound)
+	if v.config.Stats != nil {
+		v.config.Stats.WeaponStats(weapon).Shots += weapon.BurstSize
+	}
 
 	targetPos := &v.state.enemy.body.Pos
 	for i := 0; i < weapon.BurstSize; i++ {
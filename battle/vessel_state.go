@@ -24,14 +24,40 @@ type vesselState struct {
 	weapon          *weapon
 	secondaryWeapon *weapon
 
+	ability *ability
+
 	design *gamedata.VesselDesign
+
+	// damageTaken is not affected by the healing.
+	damageTaken float64
+
+	// reloadMultiplier is affected by the gunner skill.
+	reloadMultiplier float64
+
+	// These are the design stats with the equipment modules applied.
+	maxHP         float64
+	maxEnergy     float64
+	energyRegen   float64
+	maxSpeed      float64
+	acceleration  float64
+	rotationSpeed gmath.Rad
+	shieldAbsorb  float64
 }
 
 func (state *vesselState) Init() {
-	state.hp = state.design.MaxHP
-	state.energy = state.design.MaxEnergy * 0.5
+	modules := state.design.ModuleTotals()
+	state.maxHP = state.design.MaxHP + modules.MaxHP
+	state.maxEnergy = state.design.MaxEnergy + modules.MaxEnergy
+	state.energyRegen = state.design.EnergyRegen + modules.EnergyRegen
+	state.maxSpeed = state.design.MaxSpeed + modules.MaxSpeed
+	state.acceleration = state.design.Acceleration + modules.Acceleration
+	state.rotationSpeed = state.design.RotationSpeed + modules.RotationSpeed
+	state.shieldAbsorb = modules.ShieldAbsorb
+
+	state.hp = state.maxHP
+	state.energy = state.maxEnergy * 0.5
 
-	state.energyRegenThreshold = state.design.MaxEnergy * 0.5
+	state.energyRegenThreshold = state.maxEnergy * 0.5
 
 	if state.design.MainWeapon != nil {
 		state.weapon = &weapon{
@@ -44,9 +70,23 @@ func (state *vesselState) Init() {
 			reload: 2,
 		}
 	}
+	if state.design.Ability != nil {
+		state.ability = newAbility(state.design.Ability)
+	}
+}
+
+func (state *vesselState) CanUseAbility() bool {
+	return state.ability != nil && state.ability.cooldown == 0
+}
+
+func (state *vesselState) IsInvulnerable() bool {
+	return state.ability != nil && state.ability.shieldTime > 0
 }
 
 func (state *vesselState) Tick(delta float64) {
+	if state.ability != nil {
+		state.ability.Tick(delta)
+	}
 	if state.weapon != nil {
 		state.weapon.Tick(delta)
 	}
@@ -55,9 +95,14 @@ func (state *vesselState) Tick(delta float64) {
 	}
 
 	if state.energy < state.energyRegenThreshold {
-		state.energy = gmath.ClampMax(state.energy+state.design.EnergyRegen*delta, state.energyRegenThreshold)
+		state.energy = gmath.ClampMax(state.energy+state.TotalEnergyRegen()*delta, state.energyRegenThreshold)
 	}
 
+	if state.IsInvulnerable() {
+		// A spinning shield covers the vessel from all directions.
+		state.shieldRotation = (state.shieldRotation + gmath.Rad(12*delta)).Normalized()
+		return
+	}
 	state.shieldRotation = state.shieldRotation.RotatedTowards(*state.Rotation, gmath.Rad(1.75*delta))
 }
 
@@ -85,34 +130,51 @@ func (state *vesselState) CanFire() bool {
 }
 
 func (state *vesselState) FireSecondary() {
-	state.secondaryWeapon.reload = state.secondaryWeapon.design.Reload
+	state.secondaryWeapon.reload = state.secondaryWeapon.design.Reload * state.reloadMultiplier
 }
 
 func (state *vesselState) Fire() {
 	if state.weapon.design.EnergyCost != 0 {
 		state.energy -= state.weapon.design.EnergyCost
 	}
-	state.weapon.reload = state.weapon.design.Reload
+	state.weapon.reload = state.weapon.design.Reload * state.reloadMultiplier
 }
 
 func (state *vesselState) HealthPercentage() float64 {
-	return state.hp / state.design.MaxHP
+	return state.hp / state.TotalMaxHP()
 }
 
 func (state *vesselState) EnergyPercentage() float64 {
-	return state.energy / state.design.MaxEnergy
+	return state.energy / state.TotalMaxEnergy()
+}
+
+func (state *vesselState) TotalMaxHP() float64 {
+	return state.maxHP
+}
+
+func (state *vesselState) TotalMaxEnergy() float64 {
+	return state.maxEnergy
+}
+
+func (state *vesselState) TotalEnergyRegen() float64 {
+	return state.energyRegen
 }
 
 func (state *vesselState) TotalRotationSpeed() gmath.Rad {
-	return state.design.RotationSpeed
+	return state.rotationSpeed
 }
 
 func (state *vesselState) TotalMaxSpeed() float64 {
-	return state.design.MaxSpeed
+	return state.maxSpeed
 }
 
 func (state *vesselState) TotalAcceleration() float64 {
-	return state.design.Acceleration
+	return state.acceleration
+}
+
+// TotalShieldDamageMultiplier is applied to the damage blocked by the shield.
+func (state *vesselState) TotalShieldDamageMultiplier() float64 {
+	return 0.25 * (1 - state.shieldAbsorb)
 }
 
 func (state *vesselState) TotalVelocity() gmath.Vec {
@@ -9,6 +9,7 @@ import (
 	"github.com/quasilyte/vcgj7-game/assets"
 	"github.com/quasilyte/vcgj7-game/controls"
 	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
 	"github.com/quasilyte/vcgj7-game/scenes"
 	"github.com/quasilyte/vcgj7-game/session"
 )
@@ -24,10 +25,15 @@ func main() {
 
 	ctx.Loader.OpenAssetFunc = assets.MakeOpenAssetFunc(ctx)
 	assets.RegisterResources(ctx)
+	if err := loadGameData(); err != nil {
+		panic(err)
+	}
 
 	state := &session.State{
 		UIResources: eui.PrepareResources(ctx.Loader),
 		Settings:    getDefaultSettings(),
+
+		LastWorldConfig: gamedata.DefaultWorldConfig(),
 	}
 
 	if err := ctx.LoadGameData("save", &state.Settings); err != nil {
@@ -35,6 +41,10 @@ func main() {
 		state.Settings = getDefaultSettings()
 		ctx.SaveGameData("save", state.Settings)
 	}
+	if err := ctx.LoadGameData("achievements", &state.Achievements); err != nil {
+		fmt.Printf("can't load achievements: %v", err)
+		state.Achievements = gamedata.AchievementSet{}
+	}
 
 	keymap := input.Keymap{
 		controls.ActionForward:     {input.KeyUp, input.KeyW, input.KeyGamepadUp},
@@ -42,6 +52,9 @@ func main() {
 		controls.ActionRight:       {input.KeyRight, input.KeyD, input.KeyGamepadRight},
 		controls.ActionFire:        {input.KeyO, input.KeyZ, input.KeyMouseLeft},
 		controls.ActionFireSpecial: {input.KeyP, input.KeyX, input.KeyMouseRight},
+		controls.ActionAbility:     {input.KeySpace, input.KeyC, input.KeyMouseMiddle},
+		controls.ActionScrollUp:    {input.KeyUp, input.KeyWheelUp},
+		controls.ActionScrollDown:  {input.KeyDown, input.KeyWheelDown},
 		controls.ActionChoice1:     {input.Key1},
 		controls.ActionChoice2:     {input.Key2},
 		controls.ActionChoice3:     {input.Key3},
@@ -58,9 +71,26 @@ func main() {
 	}
 }
 
+func loadGameData() error {
+	randomEvents, err := assets.ReadDataFiles("events")
+	if err != nil {
+		return err
+	}
+	if err := gamedata.LoadRandomEvents(randomEvents); err != nil {
+		return err
+	}
+
+	textQuests, err := assets.ReadDataFiles("textquests")
+	if err != nil {
+		return err
+	}
+	return gamedata.LoadTextQuests(textQuests)
+}
+
 func getDefaultSettings() session.Settings {
 	return session.Settings{
 		SoundLevel: 3,
 		MusicLevel: 2,
+		Difficulty: int(gamedata.DifficultyNormal),
 	}
 }
@@ -14,6 +14,10 @@ const (
 	ActionForward
 	ActionFire
 	ActionFireSpecial
+	ActionAbility
+
+	ActionScrollUp
+	ActionScrollDown
 
 	ActionChoice1
 	ActionChoice2
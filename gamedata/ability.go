@@ -0,0 +1,78 @@
+package gamedata
+
+type AbilityKind int
+
+const (
+	AbilityUnknown AbilityKind = iota
+	AbilityEmergencyShield
+	AbilityBlink
+	AbilityEMP
+	AbilityRepairBurst
+)
+
+// BattleAbility is an activatable battle effect with a cooldown.
+// The player gets it from the active artifacts,
+// the computer pilots may have their own.
+type BattleAbility struct {
+	Name string
+	Kind AbilityKind
+
+	// Cooldown is measured in seconds.
+	Cooldown float64
+
+	// Power meaning depends on the ability kind:
+	//   - emergency shield: invulnerability duration (seconds)
+	//   - blink: teleport distance
+	//   - EMP: drained enemy energy
+	//   - repair burst: restored health (percentage)
+	Power float64
+}
+
+var (
+	abilityEmergencyShield = &BattleAbility{
+		Name:     "Emergency Shield",
+		Kind:     AbilityEmergencyShield,
+		Cooldown: 14,
+		Power:    2,
+	}
+
+	abilityBlink = &BattleAbility{
+		Name:     "Blink",
+		Kind:     AbilityBlink,
+		Cooldown: 8,
+		Power:    160,
+	}
+
+	abilityEMP = &BattleAbility{
+		Name:     "EMP",
+		Kind:     AbilityEMP,
+		Cooldown: 12,
+		Power:    60,
+	}
+
+	abilityRepairBurst = &BattleAbility{
+		Name:     "Repair Burst",
+		Kind:     AbilityRepairBurst,
+		Cooldown: 20,
+		Power:    0.2,
+	}
+)
+
+// EnemyAbilities are used by the elite computer pilots.
+var EnemyAbilities = []*BattleAbility{
+	abilityEmergencyShield,
+	abilityBlink,
+	abilityEMP,
+	abilityRepairBurst,
+}
+
+// BattleAbility returns the ability of the first active artifact.
+// Only one active artifact can be used in battle.
+func (p *Player) BattleAbility() *BattleAbility {
+	for _, a := range p.Artifacts {
+		if a.Ability != nil {
+			return a.Ability
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/ge/xslices"
+)
+
+// AchievementDesign describes an achievement and its unlock conditions.
+// Every condition is a hook; the achievement is unlocked when any hook reports true.
+type AchievementDesign struct {
+	ID          string
+	Name        string
+	Description string
+
+	// OnHour is called for every in-game hour.
+	OnHour func(w *World) bool
+
+	// OnBattleVictory is called after the enemy vessel is destroyed.
+	OnBattleVictory func(w *World, b *BattleVictoryInfo) bool
+
+	// OnGameVictory is called when the game is won.
+	OnGameVictory func(w *World) bool
+}
+
+type BattleVictoryInfo struct {
+	Enemy  *Vessel
+	Pirate bool
+
+	// DamageTaken is a total damage the player vessel received during the battle.
+	// The healing during the battle doesn't reduce it.
+	DamageTaken float64
+
+	// WeaponsUsed lists the player weapons that hit the enemy.
+	WeaponsUsed []string
+}
+
+// AchievementSet is a persistent list of unlocked achievements.
+// It's saved along with the game settings.
+type AchievementSet struct {
+	Unlocked []string
+}
+
+func (s *AchievementSet) Has(id string) bool {
+	return xslices.Contains(s.Unlocked, id)
+}
+
+var Achievements = []*AchievementDesign{
+	{
+		ID:          "first_blood",
+		Name:        "First Blood",
+		Description: "Win your first battle",
+		OnBattleVictory: func(w *World, b *BattleVictoryInfo) bool {
+			return true
+		},
+	},
+
+	{
+		ID:          "untouchable",
+		Name:        "Untouchable",
+		Description: "Win a battle without losing any vessel structure",
+		OnBattleVictory: func(w *World, b *BattleVictoryInfo) bool {
+			return b.DamageTaken <= 0
+		},
+	},
+
+	{
+		ID:          "photon_purist",
+		Name:        "Photon Purist",
+		Description: "Destroy an elite vessel using only the Photon Cannon",
+		OnBattleVictory: func(w *World, b *BattleVictoryInfo) bool {
+			return b.Enemy.Design.Elite &&
+				len(b.WeaponsUsed) == 1 &&
+				b.WeaponsUsed[0] == "Photon Cannon"
+		},
+	},
+
+	{
+		ID:          "second_wind",
+		Name:        "Second Wind",
+		Description: "Defeat a pirate after retreating from two pirate fights",
+		OnBattleVictory: func(w *World, b *BattleVictoryInfo) bool {
+			return b.Pirate && w.Stats.PirateRetreats >= 2
+		},
+	},
+
+	{
+		ID:          "collector",
+		Name:        "Collector",
+		Description: "Find 3 artifacts in a single run",
+		OnHour: func(w *World) bool {
+			return w.Stats.ArtifactsFound >= 3
+		},
+	},
+
+	{
+		ID:          "beta_breaker",
+		Name:        "Beta Breaker",
+		Description: "Drive the Beta faction out of the starting system within 10 days",
+		OnHour: func(w *World) bool {
+			if w.GameTime > 10*24 || w.Player.Faction == FactionB {
+				return false
+			}
+			numPlanets := 0
+			for _, p := range w.Systems[0].Planets {
+				if p.InitialFaction != FactionB {
+					continue
+				}
+				if p.Faction == FactionB {
+					return false
+				}
+				numPlanets++
+			}
+			return numPlanets != 0
+		},
+	},
+
+	{
+		ID:          "liberator",
+		Name:        "Liberator",
+		Description: "Win the game",
+		OnGameVictory: func(w *World) bool {
+			return true
+		},
+	},
+
+	{
+		ID:          "bare_essentials",
+		Name:        "Bare Essentials",
+		Description: "Win the game without buying any weapons",
+		OnGameVictory: func(w *World) bool {
+			return w.Stats.WeaponsBought == 0
+		},
+	},
+}
+
+func (w *World) unlockAchievement(a *AchievementDesign) {
+	w.Achievements.Unlocked = append(w.Achievements.Unlocked, a.ID)
+	w.NewAchievements = append(w.NewAchievements, a)
+}
+
+func (w *World) runAchievementHooks(check func(a *AchievementDesign) bool) {
+	if w.Achievements == nil {
+		return
+	}
+	for _, a := range Achievements {
+		if w.Achievements.Has(a.ID) {
+			continue
+		}
+		if check(a) {
+			w.unlockAchievement(a)
+		}
+	}
+}
+
+func (w *World) RunHourAchievements() {
+	w.runAchievementHooks(func(a *AchievementDesign) bool {
+		return a.OnHour != nil && a.OnHour(w)
+	})
+}
+
+func (w *World) RunBattleVictoryAchievements(b *BattleVictoryInfo) {
+	w.runAchievementHooks(func(a *AchievementDesign) bool {
+		return a.OnBattleVictory != nil && a.OnBattleVictory(w, b)
+	})
+}
+
+func (w *World) RunGameVictoryAchievements() {
+	w.runAchievementHooks(func(a *AchievementDesign) bool {
+		return a.OnGameVictory != nil && a.OnGameVictory(w)
+	})
+}
@@ -0,0 +1,336 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+type ArtifactRarity int
+
+const (
+	RarityCommon ArtifactRarity = iota
+	RarityRare
+	RarityLegendary
+)
+
+func (r ArtifactRarity) Name() string {
+	switch r {
+	case RarityCommon:
+		return "common"
+	case RarityRare:
+		return "rare"
+	case RarityLegendary:
+		return "legendary"
+	default:
+		return "?"
+	}
+}
+
+// DropWeight is a relative probability of finding an artifact of this rarity.
+func (r ArtifactRarity) DropWeight() float64 {
+	switch r {
+	case RarityCommon:
+		return 1.0
+	case RarityRare:
+		return 0.5
+	default:
+		return 0.2
+	}
+}
+
+// ArtifactDesign describes an artifact and its effects.
+// Every effect is implemented as a hook; nil hooks are ignored.
+type ArtifactDesign struct {
+	Name        string
+	Description string
+	Rarity      ArtifactRarity
+
+	// Ability is set for the active artifacts that can be used in battle.
+	Ability *BattleAbility
+
+	// OnHour is called for every in-game hour.
+	OnHour func(ctx ArtifactContext)
+
+	// OnJump is called when a jump route is calculated.
+	OnJump func(ctx ArtifactContext, j *JumpInfo)
+
+	// OnScan is called when the area scouting time is calculated.
+	OnScan func(ctx ArtifactContext, hours *int)
+
+	// OnBattleStart is called right after the battle vessels are created.
+	OnBattleStart func(ctx ArtifactContext, b *BattleStartInfo)
+
+	// OnDamage is called when the player vessel takes damage in battle.
+	OnDamage func(ctx ArtifactContext, d *DamageInfo)
+
+	// OnReward is called before the reward is given to the player.
+	OnReward func(ctx ArtifactContext, r *Reward)
+
+	// OnDocking is called when the player docks at the planet.
+	OnDocking func(ctx ArtifactContext)
+}
+
+type ArtifactContext struct {
+	Rand   *gmath.Rand
+	Player *Player
+}
+
+type JumpInfo struct {
+	Dist     float64
+	FuelCost int
+	Hours    int
+}
+
+type BattleStartInfo struct {
+	// These values are percentages.
+	HP      float64
+	Energy  float64
+	EnemyHP float64
+}
+
+type DamageInfo struct {
+	Damage  float64
+	Blocked bool
+}
+
+type RewardSource int
+
+const (
+	RewardBattle RewardSource = iota
+	RewardMinerals
+)
+
+type Reward struct {
+	Source RewardSource
+
+	Experience int
+	Credits    int
+	Cargo      int
+	Fuel       int
+
+	// Failed is set when the activity gives nothing (like a fruitless minerals hunt).
+	Failed bool
+}
+
+var Artifacts = []*ArtifactDesign{
+	{
+		Name:        "Fuel Generator",
+		Description: "passively generates fuel",
+		Rarity:      RarityCommon,
+		OnHour: func(ctx ArtifactContext) {
+			p := ctx.Player
+			if p.Mode.IsIdleInSpace() && ctx.Rand.Chance(0.6) {
+				p.Fuel = gmath.ClampMax(p.Fuel+1, p.MaxFuel)
+			}
+		},
+	},
+
+	{
+		Name:        "Repair Bots",
+		Description: "repairs the vessel hull over time",
+		Rarity:      RarityRare,
+		OnHour: func(ctx ArtifactContext) {
+			p := ctx.Player
+			if p.Mode.IsIdleInSpace() && ctx.Rand.Chance(0.8) {
+				p.VesselHP = gmath.ClampMax(p.VesselHP+0.02, 1.0)
+			}
+		},
+	},
+
+	{
+		Name:        "Scantide",
+		Description: "makes scanning faster",
+		Rarity:      RarityCommon,
+		OnScan: func(ctx ArtifactContext, hours *int) {
+			*hours = 1
+		},
+	},
+
+	{
+		Name:        "Lucky Charm",
+		Description: "more minerals are found during the hunt",
+		Rarity:      RarityRare,
+		OnReward: func(ctx ArtifactContext, r *Reward) {
+			if r.Source != RewardMinerals {
+				return
+			}
+			r.Failed = false
+			r.Cargo += ctx.Rand.IntRange(4, 14)
+		},
+	},
+
+	{
+		Name:        "Jumper",
+		Description: "makes jumps cost less fuel",
+		Rarity:      RarityRare,
+		OnJump: func(ctx ArtifactContext, j *JumpInfo) {
+			j.FuelCost = gmath.ClampMin(j.FuelCost-10, 1)
+		},
+	},
+
+	{
+		Name:        "Capacitor Core",
+		Description: "battles start with full energy",
+		Rarity:      RarityCommon,
+		OnBattleStart: func(ctx ArtifactContext, b *BattleStartInfo) {
+			b.Energy = 1.0
+		},
+	},
+
+	{
+		Name:        "Salvage Drone",
+		Description: "collects extra cargo after battles",
+		Rarity:      RarityCommon,
+		OnReward: func(ctx ArtifactContext, r *Reward) {
+			if r.Source == RewardBattle {
+				r.Cargo += ctx.Rand.IntRange(2, 6)
+			}
+		},
+	},
+
+	{
+		Name:        "Bounty Ledger",
+		Description: "more credits for battle victories",
+		Rarity:      RarityRare,
+		OnReward: func(ctx ArtifactContext, r *Reward) {
+			if r.Source == RewardBattle {
+				r.Credits += ctx.Rand.IntRange(5, 15)
+			}
+		},
+	},
+
+	{
+		Name:        "Diplomatic Seal",
+		Description: "docking improves your reputation",
+		Rarity:      RarityRare,
+		OnDocking: func(ctx ArtifactContext) {
+			p := ctx.Player
+			if ctx.Rand.Chance(0.3) {
+				p.Reputation = gmath.ClampMax(p.Reputation+1, MaxReputation)
+			}
+		},
+	},
+
+	{
+		Name:        "Phase Mirror",
+		Description: "sometimes lets enemy projectiles pass through",
+		Rarity:      RarityLegendary,
+		OnDamage: func(ctx ArtifactContext, d *DamageInfo) {
+			if !d.Blocked && ctx.Rand.Chance(0.15) {
+				d.Damage = 0
+			}
+		},
+	},
+
+	{
+		Name:        "Nanite Hull",
+		Description: "repairs the vessel at the start of every battle",
+		Rarity:      RarityLegendary,
+		OnBattleStart: func(ctx ArtifactContext, b *BattleStartInfo) {
+			b.HP = gmath.ClampMax(b.HP+0.15, 1.0)
+		},
+	},
+
+	{
+		Name:        "Aegis Emitter",
+		Description: "activate to become invulnerable for a short time",
+		Rarity:      RarityRare,
+		Ability:     abilityEmergencyShield,
+	},
+
+	{
+		Name:        "Blink Drive",
+		Description: "activate to teleport forward",
+		Rarity:      RarityRare,
+		Ability:     abilityBlink,
+	},
+
+	{
+		Name:        "EMP Coil",
+		Description: "activate to drain the enemy energy",
+		Rarity:      RarityCommon,
+		Ability:     abilityEMP,
+	},
+
+	{
+		Name:        "Nanoforge",
+		Description: "activate to repair the vessel mid-battle",
+		Rarity:      RarityLegendary,
+		Ability:     abilityRepairBurst,
+	},
+}
+
+func FindArtifactDesign(name string) *ArtifactDesign {
+	for _, a := range Artifacts {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func (p *Player) artifactContext(rand *gmath.Rand) ArtifactContext {
+	return ArtifactContext{Rand: rand, Player: p}
+}
+
+func (p *Player) RunHourHooks(rand *gmath.Rand) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnHour != nil {
+			a.OnHour(ctx)
+		}
+	}
+}
+
+func (p *Player) RunJumpHooks(rand *gmath.Rand, j *JumpInfo) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnJump != nil {
+			a.OnJump(ctx, j)
+		}
+	}
+}
+
+func (p *Player) RunScanHooks(rand *gmath.Rand, hours *int) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnScan != nil {
+			a.OnScan(ctx, hours)
+		}
+	}
+}
+
+func (p *Player) RunBattleStartHooks(rand *gmath.Rand, b *BattleStartInfo) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnBattleStart != nil {
+			a.OnBattleStart(ctx, b)
+		}
+	}
+}
+
+func (p *Player) RunDamageHooks(rand *gmath.Rand, d *DamageInfo) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnDamage != nil {
+			a.OnDamage(ctx, d)
+		}
+	}
+}
+
+func (p *Player) RunRewardHooks(rand *gmath.Rand, r *Reward) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnReward != nil {
+			a.OnReward(ctx, r)
+		}
+	}
+}
+
+func (p *Player) RunDockingHooks(rand *gmath.Rand) {
+	ctx := p.artifactContext(rand)
+	for _, a := range p.Artifacts {
+		if a.OnDocking != nil {
+			a.OnDocking(ctx)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package gamedata
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+const (
+	// LoanDailyInterest is applied to the debt every day.
+	LoanDailyInterest = 0.03
+
+	// DepositDailyInterest is applied to the deposit every day.
+	DepositDailyInterest = 0.01
+
+	// LoanTermDays is a number of days the player has to repay the debt.
+	// After that, the debt becomes overdue.
+	LoanTermDays = 7
+
+	// LoanStep is the amount of credits given by a single loan.
+	LoanStep = 100
+
+	// SeizedCargoPrice is how much credits a single seized cargo unit pays off.
+	SeizedCargoPrice = 3
+)
+
+// BankAccount is shared between all allied banks.
+type BankAccount struct {
+	Deposit int
+	Debt    int
+
+	// DebtDays is a number of days since the first unpaid loan was taken.
+	DebtDays int
+}
+
+func (a *BankAccount) IsOverdue() bool {
+	return a.Debt > 0 && a.DebtDays > LoanTermDays
+}
+
+// OverdueDays returns the number of days the debt is overdue.
+func (a *BankAccount) OverdueDays() int {
+	if !a.IsOverdue() {
+		return 0
+	}
+	return a.DebtDays - LoanTermDays
+}
+
+// MaxLoan is the total debt limit; it depends on rank and reputation.
+// The banks don't give any credits to the captains with bad reputation.
+func (p *Player) MaxLoan() int {
+	if p.Reputation < -20 {
+		return 0
+	}
+	return LoanStep + 50*GetRank(p.Experience) + gmath.ClampMin(p.Reputation, 0)
+}
+
+// ApplyDailyInterest updates the account at the daily tick.
+// It returns the deposit income.
+func (a *BankAccount) ApplyDailyInterest() int {
+	income := 0
+	if a.Deposit > 0 {
+		income = gmath.ClampMin(int(float64(a.Deposit)*DepositDailyInterest), 1)
+		a.Deposit += income
+	}
+	if a.Debt > 0 {
+		a.Debt += int(math.Ceil(float64(a.Debt) * LoanDailyInterest))
+		a.DebtDays++
+	}
+	return income
+}
+
+// Repay reduces the debt by up to the given amount and
+// returns the amount that was actually used.
+func (a *BankAccount) Repay(amount int) int {
+	amount = gmath.ClampMax(amount, a.Debt)
+	a.Debt -= amount
+	if a.Debt == 0 {
+		a.DebtDays = 0
+	}
+	return amount
+}
@@ -0,0 +1,44 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// ContrabandFine is a customs fine per contraband unit, before the difficulty multiplier.
+const ContrabandFine = 8
+
+func factionCustomsChance(f Faction) float64 {
+	switch f {
+	case FactionB:
+		return 0.25
+	case FactionC:
+		return 0.45
+	default:
+		return 0.35
+	}
+}
+
+// CustomsScanChance returns the probability of a customs scan at the planet.
+// Only the controlled planets that ban the contraband do the scans.
+// The hostile customs are more vigilant; sneaking makes the scan less likely.
+func (w *World) CustomsScanChance(p *Planet) float64 {
+	player := w.Player
+	if p.Faction == FactionNone || p.Market.ContrabandLegal || player.Contraband == 0 {
+		return 0
+	}
+	chance := factionCustomsChance(p.Faction)
+	if p.Faction != player.Faction {
+		chance *= 1.5
+	}
+	if player.Mode == ModeSneaking {
+		chance *= 0.25
+	}
+	return chance
+}
+
+// clampCargo makes the cargo and contraband fit into the cargo hold.
+// The minerals have priority over the contraband.
+func (p *Player) clampCargo() {
+	p.Cargo = gmath.ClampMax(p.Cargo, p.MaxCargo)
+	p.Contraband = gmath.ClampMax(p.Contraband, p.MaxCargo-p.Cargo)
+}
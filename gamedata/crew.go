@@ -0,0 +1,160 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+type CrewRole int
+
+const (
+	CrewEngineer CrewRole = iota
+	CrewNavigator
+	CrewGunner
+	CrewScientist
+	NumCrewRoles
+)
+
+func (role CrewRole) Name() string {
+	switch role {
+	case CrewEngineer:
+		return "Engineer"
+	case CrewNavigator:
+		return "Navigator"
+	case CrewGunner:
+		return "Gunner"
+	case CrewScientist:
+		return "Scientist"
+	default:
+		return "?"
+	}
+}
+
+const MaxCrewLevel = 5
+
+type CrewMember struct {
+	Name string
+	Role CrewRole
+
+	Level int
+
+	// Experience is a progress towards the next skill level.
+	Experience int
+
+	// InjuryTime is a number of hours before an injured crew member
+	// can use their skill again.
+	InjuryTime int
+}
+
+var crewFirstNames = []string{
+	"Ash", "Bo", "Cass", "Dario", "Eli", "Fenn", "Gray", "Hale",
+	"Ira", "Jules", "Kai", "Lior", "Mika", "Noor", "Oren", "Pax",
+	"Quinn", "Rin", "Sol", "Tam", "Vik", "Wren", "Yuri", "Zan",
+}
+
+var crewLastNames = []string{
+	"Abara", "Brand", "Corvin", "Dace", "Edris", "Falk", "Garo", "Holt",
+	"Ives", "Joss", "Kerr", "Lund", "Marek", "Novak", "Orlov", "Pryce",
+	"Rook", "Sato", "Tarn", "Ulm", "Vance", "Weir", "Yost", "Zell",
+}
+
+func NewCrewMember(rand *gmath.Rand, role CrewRole, level int) *CrewMember {
+	return &CrewMember{
+		Name:  gmath.RandElem(rand, crewFirstNames) + " " + gmath.RandElem(rand, crewLastNames),
+		Role:  role,
+		Level: gmath.Clamp(level, 1, MaxCrewLevel),
+	}
+}
+
+// Salary is a daily payment for this crew member.
+func (c *CrewMember) Salary() int {
+	return 1 + 2*c.Level
+}
+
+// HirePrice is a one-time payment required to hire this crew member.
+func (c *CrewMember) HirePrice() int {
+	return 15 + 25*c.Level
+}
+
+func (c *CrewMember) IsInjured() bool {
+	return c.InjuryTime > 0
+}
+
+// AddExperience increases the skill progress and reports whether
+// the crew member has reached the next skill level.
+func (c *CrewMember) AddExperience(amount int) bool {
+	if c.Level >= MaxCrewLevel {
+		return false
+	}
+	c.Experience += amount
+	required := 6 * c.Level
+	if c.Experience < required {
+		return false
+	}
+	c.Experience -= required
+	c.Level++
+	return true
+}
+
+// CrewSkill returns an active skill level for the given role.
+// The missing or injured crew members have a zero skill level.
+func (p *Player) CrewSkill(role CrewRole) int {
+	c := p.Crew[role]
+	if c == nil || c.IsInjured() {
+		return 0
+	}
+	return c.Level
+}
+
+// TrainCrew gives skill experience to the crew member with the given role.
+func (p *Player) TrainCrew(role CrewRole, amount int) {
+	c := p.Crew[role]
+	if c == nil || c.IsInjured() {
+		return
+	}
+	c.AddExperience(amount)
+}
+
+func (p *Player) CrewSalary() int {
+	total := 0
+	for _, c := range p.Crew {
+		if c != nil {
+			total += c.Salary()
+		}
+	}
+	return total
+}
+
+func (p *Player) NumCrew() int {
+	n := 0
+	for _, c := range p.Crew {
+		if c != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// RepairPriceMultiplier is affected by the engineer skill.
+func (p *Player) RepairPriceMultiplier() float64 {
+	return 1.0 - 0.08*float64(p.CrewSkill(CrewEngineer))
+}
+
+// RepairTimeMultiplier is affected by the engineer skill.
+func (p *Player) RepairTimeMultiplier() float64 {
+	return 1.0 - 0.1*float64(p.CrewSkill(CrewEngineer))
+}
+
+// JumpFuelMultiplier is affected by the navigator skill.
+func (p *Player) JumpFuelMultiplier() float64 {
+	return 1.0 - 0.06*float64(p.CrewSkill(CrewNavigator))
+}
+
+// ReloadMultiplier is affected by the gunner skill.
+func (p *Player) ReloadMultiplier() float64 {
+	return 1.0 - 0.05*float64(p.CrewSkill(CrewGunner))
+}
+
+// ScanTime is affected by the scientist skill and the scanner modules.
+func (p *Player) ScanTime() int {
+	return gmath.ClampMin(3-(p.CrewSkill(CrewScientist)+1)/2-p.ModuleScanTimeBonus(), 1)
+}
@@ -0,0 +1,82 @@
+package gamedata
+
+import (
+	"math"
+)
+
+type Difficulty int
+
+const (
+	// DifficultyNormal is a zero value, so the settings saved
+	// before the difficulty setting existed get the normal difficulty.
+	DifficultyNormal Difficulty = iota
+	DifficultyEasy
+	DifficultyHard
+	NumDifficulties
+)
+
+// DifficultyPreset is a set of multipliers that are applied to the game balance values.
+// The normal difficulty preset doesn't change anything.
+type DifficultyPreset struct {
+	Name string
+
+	EnemyHPMultiplier     float64
+	EnemyEnergyMultiplier float64
+	EliteChance           float64
+
+	// ChallengeProgression scales the number of battles
+	// that is used to select the enemy challenge tier.
+	// Lower values keep the weaker enemies around for longer.
+	ChallengeProgression float64
+
+	EncounterMultiplier float64
+
+	// PriceMultiplier affects the equipment shop, shipyard and repair prices.
+	PriceMultiplier float64
+
+	SalaryMultiplier float64
+
+	// EnemyProductionMultiplier scales the enemy planets vessel production time.
+	EnemyProductionMultiplier float64
+}
+
+var DifficultyPresets = [NumDifficulties]*DifficultyPreset{
+	DifficultyNormal: {
+		Name:                      "normal",
+		EnemyHPMultiplier:         1.0,
+		EnemyEnergyMultiplier:     1.0,
+		EliteChance:               0.2,
+		ChallengeProgression:      1.0,
+		EncounterMultiplier:       1.0,
+		PriceMultiplier:           1.0,
+		SalaryMultiplier:          1.0,
+		EnemyProductionMultiplier: 1.0,
+	},
+	DifficultyEasy: {
+		Name:                      "easy",
+		EnemyHPMultiplier:         0.8,
+		EnemyEnergyMultiplier:     0.85,
+		EliteChance:               0.1,
+		ChallengeProgression:      0.6,
+		EncounterMultiplier:       0.7,
+		PriceMultiplier:           0.8,
+		SalaryMultiplier:          1.25,
+		EnemyProductionMultiplier: 1.3,
+	},
+	DifficultyHard: {
+		Name:                      "hard",
+		EnemyHPMultiplier:         1.2,
+		EnemyEnergyMultiplier:     1.15,
+		EliteChance:               0.3,
+		ChallengeProgression:      1.5,
+		EncounterMultiplier:       1.3,
+		PriceMultiplier:           1.25,
+		SalaryMultiplier:          0.8,
+		EnemyProductionMultiplier: 0.75,
+	},
+}
+
+// Price applies the difficulty price multiplier to the base price.
+func (w *World) Price(base int) int {
+	return int(math.Ceil(float64(base) * w.Difficulty.PriceMultiplier))
+}
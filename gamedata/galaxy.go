@@ -0,0 +1,112 @@
+package gamedata
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+const MaxGalaxySystems = 4
+
+// Hyperjumps between the star systems are much more expensive than
+// the regular in-system jumps.
+const (
+	HyperjumpFuelBase  = 30
+	HyperjumpHoursBase = 18
+)
+
+// System is a star system of the galaxy.
+type System struct {
+	Name string
+
+	// GalaxyOffset is a star position on the galaxy map.
+	GalaxyOffset gmath.Vec
+
+	// MapSprite is a frame index inside the map stars image.
+	MapSprite int
+
+	Planets []*Planet
+}
+
+// EntryPlanet is a planet the player arrives at after a hyperjump.
+// Allied planets are preferred; otherwise it's the outermost planet.
+func (s *System) EntryPlanet(f Faction) *Planet {
+	var result *Planet
+	for _, p := range s.Planets {
+		if result == nil {
+			result = p
+			continue
+		}
+		if (p.Faction == f) != (result.Faction == f) {
+			if p.Faction == f {
+				result = p
+			}
+			continue
+		}
+		if p.Info.MapOffset.DistanceTo(systemSunPos) > result.Info.MapOffset.DistanceTo(systemSunPos) {
+			result = p
+		}
+	}
+	return result
+}
+
+// CountPlanets returns the number of planets controlled by the faction.
+func (s *System) CountPlanets(f Faction) int {
+	n := 0
+	for _, p := range s.Planets {
+		if p.Faction == f {
+			n++
+		}
+	}
+	return n
+}
+
+// HyperjumpInfo returns the base hyperjump costs, before the artifact hooks are applied.
+func (p *Player) HyperjumpInfo(src, dst *System) JumpInfo {
+	dist := src.GalaxyOffset.DistanceTo(dst.GalaxyOffset)
+	fuel := (HyperjumpFuelBase + dist*0.4) * p.FuelUsage * p.JumpFuelMultiplier()
+	return JumpInfo{
+		Dist:     dist,
+		FuelCost: int(fuel),
+		Hours:    HyperjumpHoursBase + int(math.Ceil(dist/p.JumpSpeed)),
+	}
+}
+
+var systemNames = []string{
+	"Altair", "Vega", "Deneb", "Rigel", "Sirius", "Antares", "Castor", "Mira", "Achernar", "Capella",
+}
+
+func generateGalaxyPositions(rand *gmath.Rand, n int) []gmath.Vec {
+	for attempt := 0; attempt < 50; attempt++ {
+		positions := make([]gmath.Vec, 0, n)
+		for tries := 0; tries < 200 && len(positions) < n; tries++ {
+			pos := gmath.Vec{
+				X: float64(rand.IntRange(32, 128)),
+				Y: float64(rand.IntRange(20, 124)),
+			}
+			ok := true
+			for _, other := range positions {
+				// Keep the systems far enough for the labels, but
+				// not too far for the hyperjump fuel costs.
+				dist := other.DistanceTo(pos)
+				if dist < 50 || dist > 120 || (math.Abs(other.X-pos.X) < 66 && math.Abs(other.Y-pos.Y) < 30) {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				positions = append(positions, pos)
+			}
+		}
+		if len(positions) == n {
+			return positions
+		}
+	}
+
+	// A fallback diagonal layout.
+	positions := make([]gmath.Vec, n)
+	for i := range positions {
+		positions[i] = gmath.Vec{X: 30 + float64(i)*30, Y: 30 + float64(i)*30}
+	}
+	return positions
+}
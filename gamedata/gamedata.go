@@ -1,15 +1,22 @@
 package gamedata
 
 import (
+	"sort"
+
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
 )
 
+// RetreatFuelCost is how much fuel it takes to escape from the battle.
+const RetreatFuelCost = 10
+
 type BattleRewards struct {
 	Victory bool
+	Retreat bool
 
 	SystemLiberated bool
-	Artifact        string
+	Artifact        *ArtifactDesign
+	Weapon          *WeaponDesign
 	Experience      int
 	Cargo           int
 	Credits         int
@@ -19,12 +26,32 @@ type BattleRewards struct {
 type World struct {
 	Player *Player
 
+	// Planets contains the planets of all star systems.
 	Planets []*Planet
 
+	Systems []*System
+
 	GameTime int // In hours
 
+	// PlanetlessTime is the last hour when the player faction had no planets.
+	PlanetlessTime int
+
 	RecentEvents []WorldEvent
 
+	// EventLog is a complete events history, in chronological order.
+	EventLog []WorldEvent
+
+	Config     WorldConfig
+	Difficulty *DifficultyPreset
+
+	Stats RunStats
+
+	// Achievements are shared between the runs; can be nil.
+	Achievements *AchievementSet
+
+	// NewAchievements are unlocked during this run, but not announced yet.
+	NewAchievements []*AchievementDesign
+
 	NextPirateDelay float64
 	PirateSeq       int
 
@@ -35,9 +62,16 @@ type World struct {
 	QuestRerollDelay float64
 	CurrentQuest     *Quest
 
+	RandomEventDelay float64
+	SeenRandomEvents []string
+
+	TextQuest           *TextQuestState
+	CompletedTextQuests []string
+
 	Squads []*Squad
 
-	Artifacts []string
+	// Artifacts that can still be found in this game.
+	Artifacts []*ArtifactDesign
 }
 
 type Quest struct {
@@ -49,15 +83,71 @@ type Quest struct {
 	ExpReward     int
 }
 
+type WorldEventCategory int
+
+const (
+	EventCapture WorldEventCategory = iota
+	EventLoss
+	EventAttack
+	EventQuest
+	EventBattle
+	EventCrew
+	EventBank
+	NumEventCategories
+)
+
+func (c WorldEventCategory) Name() string {
+	switch c {
+	case EventCapture:
+		return "capture"
+	case EventLoss:
+		return "loss"
+	case EventAttack:
+		return "attack"
+	case EventQuest:
+		return "quest"
+	case EventBattle:
+		return "battle"
+	case EventCrew:
+		return "crew"
+	case EventBank:
+		return "bank"
+	default:
+		return "?"
+	}
+}
+
+// IsNews reports whether events of this category are broadcast by the news.
+// Other events are personal and only go to the captain's log.
+func (e WorldEvent) IsNews() bool {
+	switch e.Category {
+	case EventCapture, EventLoss, EventAttack:
+		return true
+	default:
+		return false
+	}
+}
+
 type WorldEvent struct {
 	Time int // In hours
 	Text string
+
+	Category WorldEventCategory
+
+	// Planet and Faction are optional, they're used for the log filtering.
+	// FactionNone means that the event is not bound to any faction.
+	Planet  *Planet
+	Faction Faction
 }
 
-func (w *World) PushEvent(s string) {
-	e := WorldEvent{
-		Text: s,
-		Time: w.GameTime,
+// PushEvent adds the event to the captain's log.
+// If it's a news event, it also becomes one of the recent events.
+// The event time is assigned automatically.
+func (w *World) PushEvent(e WorldEvent) {
+	e.Time = w.GameTime
+	w.EventLog = append(w.EventLog, e)
+	if !e.IsNews() {
+		return
 	}
 	const maxEvents = 5
 	if len(w.RecentEvents) >= maxEvents {
@@ -87,14 +177,16 @@ const (
 type Player struct {
 	Planet *Planet
 
-	Artifacts []string
+	Artifacts []*ArtifactDesign
 
 	Faction     Faction
 	ExtraSalary int
 
+	Crew [NumCrewRoles]*CrewMember
+
 	BattleRewards BattleRewards
 
-	ImprovedHull bool
+	Hull *HullDesign
 
 	Mode Mode
 
@@ -107,25 +199,67 @@ type Player struct {
 	VesselDesign *VesselDesign
 	VesselHP     float64 // percentage
 
+	// Insured is set if the vessel destruction is covered by an insurance policy.
+	Insured bool
+
+	// WeaponStorage contains the spare weapons kept in the cargo hold.
+	WeaponStorage []*WeaponDesign
+
 	JumpSpeed   float64
 	MaxJumpDist float64
 	FuelUsage   float64
 
+	// Destination is a route planner target; can be nil.
+	Destination *Planet
+	RouteGoal   RouteGoal
+
+	// Transit is an ongoing jump; can be nil.
+	Transit *Transit
+
 	Battles int
 
+	// Reputation is a standing within the player's faction.
+	// It's affected by the random events choices.
+	Reputation int
+
 	Experience int
 	Credits    int
+	Bank       BankAccount
 	Fuel       int
 	MaxFuel    int
 	Cargo      int
 	MaxCargo   int
+
+	// Contraband shares the cargo hold with the minerals.
+	Contraband int
+
+	// CustomsPending is set on arrival; the customs scan is rolled
+	// during the first hour spent at the planet.
+	CustomsPending bool
 }
 
+const (
+	MinReputation = -100
+	MaxReputation = 100
+)
+
 func (p *Player) HasArtifact(name string) bool {
-	return xslices.Contains(p.Artifacts, name)
+	for _, a := range p.Artifacts {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *Player) HasWeapon(w *WeaponDesign) bool {
+	if p.IsWeaponEquipped(w) {
+		return true
+	}
+	return xslices.Contains(p.WeaponStorage, w)
+}
+
+func (p *Player) IsWeaponEquipped(w *WeaponDesign) bool {
 	if w.Primary {
 		return p.VesselDesign.MainWeapon == w
 	}
@@ -133,7 +267,7 @@ func (p *Player) HasWeapon(w *WeaponDesign) bool {
 }
 
 func (p *Player) FreeCargoSpace() int {
-	return p.MaxCargo - p.Cargo
+	return p.MaxCargo - p.Cargo - p.Contraband
 }
 
 func (p *Player) LoadCargo(amount int) int {
@@ -148,8 +282,13 @@ func (p *Player) LoadCargo(amount int) int {
 type Planet struct {
 	Faction Faction
 
+	// InitialFaction is the planet owner at the start of the game.
+	InitialFaction Faction
+
 	Info *PlanetInfo
 
+	System *System
+
 	VesselProduction     bool
 	VesselProductionTime float64
 
@@ -160,34 +299,129 @@ type Planet struct {
 	MineralsDelay  float64
 	MineralDeposit int
 
-	VesselsByFaction   [NumFactions]int
+	VesselsByFaction   [NumFactions][]*Vessel
 	InfluenceByFaction [NumFactions]float64
 
 	// If not neutral.
 	AttackDelay  float64
 	CaptureDelay float64
 
+	// These are only managed by the player via the governor office.
+	Stance          PlanetStance
+	ProductionLevel int
+	DefenseLevel    int
+	GarrisonLevel   int
+
 	ShopModeWeapons bool
 	ShopSwapDelay   float64
 
 	WeaponsRerollDelay float64
 	WeaponsAvailable   []string
+	ModulesAvailable   []string
+
+	HullsAvailable []string
+
+	Market PlanetMarket
 
 	AreasVisited PlanetVisitStatus
 }
 
+type PlanetStance int
+
+const (
+	StanceBalanced PlanetStance = iota
+	StanceDefensive
+	StanceOffensive
+	numPlanetStances
+)
+
+func (s PlanetStance) Name() string {
+	switch s {
+	case StanceDefensive:
+		return "defensive"
+	case StanceOffensive:
+		return "offensive"
+	default:
+		return "balanced"
+	}
+}
+
+func (s PlanetStance) Next() PlanetStance {
+	return (s + 1) % numPlanetStances
+}
+
+const (
+	MaxPlanetProductionLevel = 3
+	MaxPlanetDefenseLevel    = 5
+	MaxPlanetGarrisonLevel   = 4
+)
+
+// GarrisonExpansion is a garrison limit increase per garrison level.
+const GarrisonExpansion = 5
+
+// ProductionTimeMultiplier is applied to the vessel production time.
+func (p *Planet) ProductionTimeMultiplier() float64 {
+	return 1.0 - 0.15*float64(p.ProductionLevel)
+}
+
+// DefenseMultiplier is applied to the garrison vessels strength during the planet battles.
+func (p *Planet) DefenseMultiplier() float64 {
+	return 1.0 + 0.2*float64(p.DefenseLevel)
+}
+
+// ResetManagement discards the governor decisions.
+// It's called when the planet changes its owner.
+func (p *Planet) ResetManagement() {
+	p.Stance = StanceBalanced
+	p.ProductionLevel = 0
+	p.DefenseLevel = 0
+	p.GarrisonLimit -= p.GarrisonLevel * GarrisonExpansion
+	p.GarrisonLevel = 0
+}
+
+func (p *Planet) NumVessels(f Faction) int {
+	return len(p.VesselsByFaction[f])
+}
+
+func (p *Planet) AddVessel(v *Vessel) {
+	p.VesselsByFaction[v.Faction] = append(p.VesselsByFaction[v.Faction], v)
+}
+
+func (p *Planet) RemoveVessel(v *Vessel) {
+	p.VesselsByFaction[v.Faction] = xslices.Remove(p.VesselsByFaction[v.Faction], v)
+}
+
+// TakeVessels removes up to n vessels of the given faction from the planet.
+// The healthiest vessels are selected first.
+func (p *Planet) TakeVessels(f Faction, n int) []*Vessel {
+	vessels := p.VesselsByFaction[f]
+	if n > len(vessels) {
+		n = len(vessels)
+	}
+	sort.SliceStable(vessels, func(i, j int) bool {
+		return vessels[i].HP < vessels[j].HP
+	})
+	taken := make([]*Vessel, n)
+	copy(taken, vessels[len(vessels)-n:])
+	p.VesselsByFaction[f] = vessels[:len(vessels)-n]
+	return taken
+}
+
 type Squad struct {
-	NumVessels int
-	Faction    Faction
+	Vessels []*Vessel
+	Faction Faction
 
 	Speed float64
 	Dist  float64
+	Src   *Planet
 	Dst   *Planet
 }
 
 type PlanetVisitStatus struct {
 	VisitedMineralsMarket bool
 	VisitedNews           bool
+	VisitedBar            bool
+	VisitedCrewAgency     bool
 }
 
 type PlanetInfo struct {
@@ -195,6 +429,9 @@ type PlanetInfo struct {
 	RealName  string
 	GasGiant  bool
 	MapOffset gmath.Vec
+
+	// MapSprite is a frame index inside the map planets image.
+	MapSprite int
 }
 
 var Planets = []*PlanetInfo{
@@ -203,6 +440,7 @@ var Planets = []*PlanetInfo{
 		RealName:  "Neptune",
 		GasGiant:  true,
 		MapOffset: gmath.Vec{X: 14, Y: 37},
+		MapSprite: 0,
 	},
 
 	{
@@ -210,6 +448,7 @@ var Planets = []*PlanetInfo{
 		RealName:  "Uranus",
 		GasGiant:  true,
 		MapOffset: gmath.Vec{X: 37, Y: 16},
+		MapSprite: 1,
 	},
 
 	{
@@ -217,6 +456,7 @@ var Planets = []*PlanetInfo{
 		RealName:  "Saturn",
 		GasGiant:  true,
 		MapOffset: gmath.Vec{X: 39, Y: 125},
+		MapSprite: 2,
 	},
 
 	{
@@ -224,29 +464,34 @@ var Planets = []*PlanetInfo{
 		RealName:  "Jupiter",
 		GasGiant:  true,
 		MapOffset: gmath.Vec{X: 38, Y: 80},
+		MapSprite: 3,
 	},
 
 	{
 		Name:      "Planet IV",
 		RealName:  "Mars",
 		MapOffset: gmath.Vec{X: 95, Y: 30},
+		MapSprite: 4,
 	},
 
 	{
 		Name:      "Planet III",
 		RealName:  "Earth",
 		MapOffset: gmath.Vec{X: 80, Y: 67},
+		MapSprite: 5,
 	},
 
 	{
 		Name:      "Planet II",
 		RealName:  "Venus",
 		MapOffset: gmath.Vec{X: 97, Y: 94},
+		MapSprite: 6,
 	},
 
 	{
 		Name:      "Planet I",
 		RealName:  "Mercury",
 		MapOffset: gmath.Vec{X: 125, Y: 102},
+		MapSprite: 7,
 	},
 }
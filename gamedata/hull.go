@@ -0,0 +1,202 @@
+package gamedata
+
+import (
+	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/assets"
+)
+
+// HullDesign is a vessel class that can be bought in a shipyard.
+// The player vessel design is derived from the hull base stats
+// and the workshop upgrades.
+type HullDesign struct {
+	Name string
+
+	Image resource.ImageID
+
+	Price int
+
+	// MinRank is required to buy this hull.
+	MinRank int
+
+	MaxHP     float64
+	MaxEnergy float64
+
+	EnergyRegen float64
+
+	MaxSpeed     float64
+	Acceleration float64
+
+	RotationSpeed gmath.Rad
+
+	MaxCargo int
+	MaxFuel  int
+
+	// Slots is a number of equipment modules this hull can carry.
+	Slots int
+
+	// Factions lists the planet owners that have this hull in their shipyards.
+	Factions []Faction
+}
+
+var Hulls = []*HullDesign{
+	{
+		Name:          "Pathfinder",
+		Image:         assets.ImageVesselPlayer,
+		Price:         150,
+		MaxHP:         120,
+		MaxEnergy:     90,
+		EnergyRegen:   1.5,
+		MaxSpeed:      150,
+		Acceleration:  75,
+		RotationSpeed: 2.4,
+		MaxCargo:      40,
+		MaxFuel:       130,
+		Slots:         2,
+		Factions:      []Faction{FactionA, FactionB, FactionC},
+	},
+
+	{
+		Name:          "Courier",
+		Image:         assets.ImageVesselCourier,
+		Price:         260,
+		MinRank:       1,
+		MaxHP:         95,
+		MaxEnergy:     80,
+		EnergyRegen:   1.7,
+		MaxSpeed:      215,
+		Acceleration:  125,
+		RotationSpeed: 3.0,
+		MaxCargo:      30,
+		MaxFuel:       190,
+		Slots:         2,
+		Factions:      []Faction{FactionA, FactionC},
+	},
+
+	{
+		Name:          "Hauler",
+		Image:         assets.ImageVesselHauler,
+		Price:         300,
+		MinRank:       2,
+		MaxHP:         150,
+		MaxEnergy:     80,
+		EnergyRegen:   1.3,
+		MaxSpeed:      130,
+		Acceleration:  55,
+		RotationSpeed: 1.9,
+		MaxCargo:      110,
+		MaxFuel:       160,
+		Slots:         2,
+		Factions:      []Faction{FactionB, FactionC},
+	},
+
+	{
+		Name:          "Warden",
+		Image:         assets.ImageVesselPlayerElite,
+		Price:         350,
+		MinRank:       2,
+		MaxHP:         170,
+		MaxEnergy:     110,
+		EnergyRegen:   1.5,
+		MaxSpeed:      150,
+		Acceleration:  75,
+		RotationSpeed: 1.6,
+		MaxCargo:      60,
+		MaxFuel:       130,
+		Slots:         3,
+		Factions:      []Faction{FactionA, FactionB},
+	},
+
+	{
+		Name:          "Gunship",
+		Image:         assets.ImageVesselGunship,
+		Price:         520,
+		MinRank:       4,
+		MaxHP:         235,
+		MaxEnergy:     140,
+		EnergyRegen:   1.9,
+		MaxSpeed:      115,
+		Acceleration:  55,
+		RotationSpeed: 1.4,
+		MaxCargo:      35,
+		MaxFuel:       110,
+		Slots:         4,
+		Factions:      []Faction{FactionA, FactionB, FactionC},
+	},
+}
+
+func FindHullDesign(name string) *HullDesign {
+	for _, h := range Hulls {
+		if h.Name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+func (h *HullDesign) SoldBy(f Faction) bool {
+	return xslices.Contains(h.Factions, f)
+}
+
+// TradeInPrice is a discount the shipyard gives for the old hull.
+func (h *HullDesign) TradeInPrice() int {
+	return h.Price / 2
+}
+
+func newHullVesselDesign(h *HullDesign) *VesselDesign {
+	return &VesselDesign{
+		Image:         h.Image,
+		MaxHP:         h.MaxHP,
+		MaxEnergy:     h.MaxEnergy,
+		EnergyRegen:   h.EnergyRegen,
+		MaxSpeed:      h.MaxSpeed,
+		Acceleration:  h.Acceleration,
+		RotationSpeed: h.RotationSpeed,
+	}
+}
+
+// ChangeHull replaces the player vessel hull.
+//
+// Upgrades are carried over using these rules:
+//   - engine upgrades (speed, acceleration, rotation) are moved to the new hull as is
+//   - armor and energy upgrades are hull-specific, only half of their bonus (and levels) is kept
+//   - lab upgrades of the fuel tank and cargo storage are kept
+//
+// Weapons and modules are moved to the new hull too.
+// The modules that don't fit into the new hull slots are sold.
+func (p *Player) ChangeHull(h *HullDesign) {
+	old := p.Hull
+	design := p.VesselDesign
+
+	for len(design.Modules) > h.Slots {
+		m := design.Modules[len(design.Modules)-1]
+		p.RemoveModule(m)
+		p.Credits += m.SellPrice()
+	}
+
+	newDesign := newHullVesselDesign(h)
+	newDesign.Faction = design.Faction
+	newDesign.MainWeapon = design.MainWeapon
+	newDesign.SecondaryWeapon = design.SecondaryWeapon
+	newDesign.Modules = design.Modules
+
+	newDesign.MaxSpeed += design.MaxSpeed - old.MaxSpeed
+	newDesign.Acceleration += design.Acceleration - old.Acceleration
+	newDesign.RotationSpeed += design.RotationSpeed - old.RotationSpeed
+
+	newDesign.MaxHP += 0.5 * (design.MaxHP - old.MaxHP)
+	newDesign.MaxEnergy += 0.5 * (design.MaxEnergy - old.MaxEnergy)
+	newDesign.EnergyRegen += 0.5 * (design.EnergyRegen - old.EnergyRegen)
+	p.ArmorLevel = 1 + (p.ArmorLevel-1)/2
+	p.EnergyLevel = 1 + (p.EnergyLevel-1)/2
+
+	p.MaxCargo = h.MaxCargo + (p.MaxCargo - old.MaxCargo)
+	p.MaxFuel = h.MaxFuel + (p.MaxFuel - old.MaxFuel)
+	p.clampCargo()
+	p.Fuel = gmath.ClampMax(p.Fuel, p.MaxFuel)
+
+	p.Hull = h
+	p.VesselDesign = newDesign
+	p.VesselHP = 1.0
+}
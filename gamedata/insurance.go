@@ -0,0 +1,79 @@
+package gamedata
+
+import (
+	"math"
+)
+
+// InsuranceHull is a basic hull the insured player gets after the vessel destruction.
+const InsuranceHull = "Pathfinder"
+
+// InsurancePrice is a policy price before the difficulty multiplier.
+// More expensive hulls and higher ranks make the policy more expensive.
+func (p *Player) InsurancePrice() int {
+	return p.Hull.Price/4 + 15*GetRank(p.Experience)
+}
+
+// NearestAlliedPlanet returns the closest allied planet; can be nil.
+// The planets of the current star system are preferred.
+func (w *World) NearestAlliedPlanet(from *Planet) *Planet {
+	var result *Planet
+	resultDist := math.MaxFloat64
+	for _, p := range w.Planets {
+		if p.Faction != w.Player.Faction {
+			continue
+		}
+		var dist float64
+		if p.System == from.System {
+			dist = from.Info.MapOffset.DistanceTo(p.Info.MapOffset)
+		} else {
+			// Any in-system planet is closer than the other star systems.
+			dist = 1000 + from.System.GalaxyOffset.DistanceTo(p.System.GalaxyOffset)
+		}
+		if dist < resultDist {
+			result = p
+			resultDist = dist
+		}
+	}
+	return result
+}
+
+// RescueVessel replaces the destroyed vessel with a basic one.
+//
+// Only the main weapon and the lab upgrades survive;
+// the cargo (including the contraband), artifacts, spare weapons, modules and workshop upgrades are lost.
+// The lost artifacts can be found again later.
+func (w *World) RescueVessel(dst *Planet) {
+	p := w.Player
+	old := p.Hull
+	h := FindHullDesign(InsuranceHull)
+
+	design := newHullVesselDesign(h)
+	design.Faction = p.Faction
+	design.MainWeapon = p.VesselDesign.MainWeapon
+
+	w.Artifacts = append(w.Artifacts, p.Artifacts...)
+	p.Artifacts = nil
+	p.WeaponStorage = nil
+
+	p.SpeedLevel = 1
+	p.AccelerationLevel = 1
+	p.RotationLevel = 1
+	p.EnergyLevel = 1
+	p.ArmorLevel = 1
+
+	// The modules are lost together with their bonuses.
+	p.MaxCargo -= p.VesselDesign.ModuleTotals().MaxCargo
+	p.MaxCargo = h.MaxCargo + (p.MaxCargo - old.MaxCargo)
+	p.MaxFuel = h.MaxFuel + (p.MaxFuel - old.MaxFuel)
+	p.Cargo = 0
+	p.Contraband = 0
+	p.Fuel = p.MaxFuel / 2
+
+	p.Hull = h
+	p.VesselDesign = design
+	p.VesselHP = 1.0
+
+	p.Planet = dst
+	p.Transit = nil
+	p.Insured = false
+}
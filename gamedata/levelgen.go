@@ -2,19 +2,30 @@ package gamedata
 
 import (
 	"github.com/quasilyte/gmath"
-	"github.com/quasilyte/vcgj7-game/assets"
 )
 
-func NewWorld(rand *gmath.Rand) *World {
-	w := &World{}
+func NewWorld(rand *gmath.Rand, config WorldConfig) *World {
+	if config.Difficulty == nil {
+		config.Difficulty = DifficultyPresets[DifficultyNormal]
+	}
+	w := &World{
+		Config:     config,
+		Difficulty: config.Difficulty,
+	}
 
+	resources := config.Resources.Multiplier()
+
+	hull := FindHullDesign("Pathfinder")
+	design := newHullVesselDesign(hull)
+	design.Faction = config.PlayerFaction
+	design.MainWeapon = FindWeaponDesign("Photon Cannon")
 	w.Player = &Player{
-		Faction:  FactionA,
+		Faction:  config.PlayerFaction,
 		VesselHP: 1.0,
 
 		Mode: ModeOrbiting,
 
-		MaxJumpDist: 60,
+		MaxJumpDist: BaseMaxJumpDist,
 		JumpSpeed:   8,
 		FuelUsage:   1.0,
 
@@ -24,42 +35,93 @@ func NewWorld(rand *gmath.Rand) *World {
 		EnergyLevel:       1,
 		ArmorLevel:        1,
 
-		Credits: rand.IntRange(110, 120),
-		Fuel:    rand.IntRange(110, 120),
-		MaxFuel: 130,
+		Credits: int(float64(rand.IntRange(110, 120)) * resources),
+		Fuel:    gmath.ClampMax(int(float64(rand.IntRange(110, 120))*resources), hull.MaxFuel),
+		MaxFuel: hull.MaxFuel,
 
 		Cargo:    0,
-		MaxCargo: 40,
-
-		VesselDesign: &VesselDesign{
-			Faction:       FactionA,
-			Image:         assets.ImageVesselPlayer,
-			MaxHP:         120,
-			MaxEnergy:     90,
-			EnergyRegen:   1.5,
-			MaxSpeed:      150,
-			Acceleration:  75,
-			RotationSpeed: 2.4,
-			MainWeapon:    FindWeaponDesign("Photon Cannon"),
-			// SecondaryWeapon: FindWeaponDesign("Mini-rocket Pod"),
-		},
+		MaxCargo: hull.MaxCargo,
+
+		Hull:         hull,
+		VesselDesign: design,
 	}
 
-	planets := make([]*Planet, len(Planets))
+	numSystems := gmath.Clamp(config.NumSystems, 1, MaxGalaxySystems)
+	galaxyPositions := generateGalaxyPositions(rand, numSystems)
+	names := make([]string, len(systemNames))
+	copy(names, systemNames)
+	gmath.Shuffle(rand, names)
+	usedPlanetNames := map[string]bool{}
+	for i := 0; i < numSystems; i++ {
+		// The starting system has all factions present.
+		// The other systems are missing one of them.
+		var layout *StarSystem
+		absentFaction := FactionNone
+		if i == 0 {
+			layout = NewStarSystem(rand, config.StarSystem, usedPlanetNames)
+		} else {
+			layout = GenerateStarSystem(rand, usedPlanetNames)
+			absentFaction = FactionA + Faction(rand.IntRange(0, int(NumFactions-FactionA)-1))
+		}
+		system := &System{
+			Name:         names[i],
+			GalaxyOffset: galaxyPositions[i],
+			MapSprite:    rand.IntRange(0, numStarSprites-1),
+		}
+		if i == 0 && config.StarSystem == StarSystemClassic {
+			system.Name = "Sol"
+			system.MapSprite = 0
+		}
+		w.Systems = append(w.Systems, system)
+		w.initSystemPlanets(rand, system, layout, absentFaction)
+		w.Planets = append(w.Planets, system.Planets...)
+		if i == 0 {
+			w.Player.Planet = system.Planets[layout.HomePlanets[config.PlayerFaction]]
+		}
+	}
+	for _, p := range w.Planets {
+		p.InitialFaction = p.Faction
+	}
+
+	w.NextPirateDelay = rand.FloatRange(250, 500)
+	w.RandomEventDelay = rand.FloatRange(20, 40)
+
+	w.PushEvent(WorldEvent{
+		Category: EventAttack,
+		Text:     "All three major factions declare war to each other",
+	})
+
+	w.Artifacts = make([]*ArtifactDesign, len(Artifacts))
+	copy(w.Artifacts, Artifacts)
+
+	return w
+}
+
+func (w *World) initSystemPlanets(rand *gmath.Rand, system *System, layout *StarSystem, absentFaction Faction) {
+	planets := make([]*Planet, len(layout.Planets))
 	for i := range planets {
 		p := &Planet{
-			Info:          Planets[i],
+			Info:          layout.Planets[i],
+			System:        system,
 			GarrisonLimit: rand.IntRange(25, 40),
+			Market:        newPlanetMarket(rand, layout.Planets[i]),
 		}
 		planets[i] = p
 	}
 
-	planets[0].Faction = FactionA
-	planets[2].Faction = FactionB
-	planets[7].Faction = FactionC
+	for f := FactionA; f < NumFactions; f++ {
+		if f == absentFaction {
+			continue
+		}
+		planets[layout.HomePlanets[f]].Faction = f
+	}
 
-	planets[1].VesselsByFaction[FactionB] = 2
-	planets[6].VesselsByFaction[FactionA] = 1
+	for _, o := range layout.Outposts {
+		if o.Faction == absentFaction {
+			continue
+		}
+		addGarrison(planets[o.Planet], o.Faction, o.NumVessels)
+	}
 
 	for _, p := range planets {
 		if p.Faction == FactionNone {
@@ -72,28 +134,14 @@ func NewWorld(rand *gmath.Rand) *World {
 		if p.Faction != w.Player.Faction {
 			numVessels += 12
 		}
-		p.VesselsByFaction[p.Faction] = numVessels
+		addGarrison(p, p.Faction, numVessels)
 	}
 
-	w.Player.Planet = planets[0]
-	w.Planets = planets
-
-	w.NextPirateDelay = rand.FloatRange(250, 500)
+	system.Planets = planets
+}
 
-	w.PushEvent("All three major factions declare war to each other")
-
-	w.Artifacts = []string{
-		// Passively generates fuel over time.
-		"Fuel Generator",
-		// Repairs vessel hull over time.
-		"Repair Bots",
-		// Faster scanning time.
-		"Scantide",
-		// More rewards in some situations.
-		"Lucky Charm",
-		// Makes jumps cost less fuel.
-		"Jumper",
+func addGarrison(p *Planet, f Faction, numVessels int) {
+	for i := 0; i < numVessels; i++ {
+		p.AddVessel(NewVessel(f))
 	}
-
-	return w
 }
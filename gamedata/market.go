@@ -0,0 +1,92 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// PlanetMarket contains the base planet prices.
+// They're rolled once per planet and then adjusted by the current
+// situation: the planet owner, the war pressure and the player reputation.
+type PlanetMarket struct {
+	// FuelPrice is a base price of a single fuel unit.
+	FuelPrice float64
+
+	// RepairPrice is a base price of a single vessel structure percent.
+	RepairPrice float64
+
+	// ContrabandLegal planets sell the contraband;
+	// the other planets buy it at a much higher price.
+	ContrabandLegal bool
+	ContrabandPrice float64
+}
+
+func newPlanetMarket(rand *gmath.Rand, info *PlanetInfo) PlanetMarket {
+	m := PlanetMarket{
+		FuelPrice:   rand.FloatRange(0.4, 0.6),
+		RepairPrice: rand.FloatRange(0.3, 0.5),
+	}
+	if rand.Chance(0.4) {
+		m.ContrabandLegal = true
+		m.ContrabandPrice = rand.FloatRange(3, 6)
+	} else {
+		m.ContrabandPrice = rand.FloatRange(10, 18)
+	}
+	if info.GasGiant {
+		// Gas giants have their own fuel refineries,
+		// but the orbital stations have less repair facilities.
+		m.FuelPrice *= 0.7
+		m.RepairPrice *= 1.2
+	}
+	return m
+}
+
+// MarketPrices are the actual planet prices.
+type MarketPrices struct {
+	Fuel   float64
+	Repair float64
+
+	// WarPressure is a price increase caused by the hostile vessels around.
+	WarPressure float64
+}
+
+func factionMarketMultipliers(f Faction) (fuel, repair float64) {
+	switch f {
+	case FactionB:
+		return 0.85, 1.15
+	case FactionC:
+		return 1.15, 0.85
+	default:
+		return 1.0, 1.0
+	}
+}
+
+// WarPressure is based on the hostile vessels at the planet and the hostile squads heading to it.
+func (w *World) WarPressure(p *Planet) float64 {
+	numHostile := 0
+	for i, vessels := range p.VesselsByFaction {
+		f := Faction(i)
+		if f != FactionNone && f != p.Faction {
+			numHostile += len(vessels)
+		}
+	}
+	for _, squad := range w.Squads {
+		if squad.Dst == p && squad.Faction != p.Faction {
+			numHostile += len(squad.Vessels)
+		}
+	}
+	return gmath.ClampMax(0.03*float64(numHostile), 0.5)
+}
+
+// MarketPrices returns the current planet prices for the player.
+func (w *World) MarketPrices(p *Planet) MarketPrices {
+	fuelMultiplier, repairMultiplier := factionMarketMultipliers(p.Faction)
+	pressure := w.WarPressure(p)
+	// A good reputation gives up to 20% discount, a bad one makes everything up to 20% more expensive.
+	reputationMultiplier := 1.0 - 0.2*float64(w.Player.Reputation)/MaxReputation
+	common := (1.0 + pressure) * reputationMultiplier * w.Difficulty.PriceMultiplier
+	return MarketPrices{
+		Fuel:        p.Market.FuelPrice * fuelMultiplier * common,
+		Repair:      p.Market.RepairPrice * repairMultiplier * common * w.Player.RepairPriceMultiplier(),
+		WarPressure: pressure,
+	}
+}
@@ -14,4 +14,19 @@ const (
 	ModeSneaking
 	ModeJustEntered
 	ModeDocked
+
+	// ModeInTransit is used when a jump was interrupted by an in-transit event.
+	// The vessel is somewhere between the planets.
+	// It should remain the last mode, see Mode.UnmarshalJSON.
+	ModeInTransit
 )
+
+// IsIdleInSpace reports whether the player vessel is near the planet and not busy with docking or combat.
+func (m Mode) IsIdleInSpace() bool {
+	switch m {
+	case ModeJustEntered, ModeOrbiting, ModeScavenging, ModeSneaking:
+		return true
+	default:
+		return false
+	}
+}
@@ -18,11 +18,12 @@ func _() {
 	_ = x[ModeSneaking-7]
 	_ = x[ModeJustEntered-8]
 	_ = x[ModeDocked-9]
+	_ = x[ModeInTransit-10]
 }
 
-const _Mode_name = "UnknownJumpOrbitingCombatAfterCombatScavengingAttackSneakingJustEnteredDocked"
+const _Mode_name = "UnknownJumpOrbitingCombatAfterCombatScavengingAttackSneakingJustEnteredDockedInTransit"
 
-var _Mode_index = [...]uint8{0, 7, 11, 19, 25, 36, 46, 52, 60, 71, 77}
+var _Mode_index = [...]uint8{0, 7, 11, 19, 25, 36, 46, 52, 60, 71, 77, 86}
 
 func (i Mode) String() string {
 	if i < 0 || i >= Mode(len(_Mode_index)-1) {
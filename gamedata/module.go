@@ -0,0 +1,134 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+)
+
+// ModuleDesign is a non-weapon vessel equipment.
+// The number of installed modules is limited by the hull slots.
+type ModuleDesign struct {
+	Name        string
+	Description string
+
+	Price int
+
+	// Battle stats bonuses.
+	MaxHP         float64
+	MaxEnergy     float64
+	EnergyRegen   float64
+	MaxSpeed      float64
+	Acceleration  float64
+	RotationSpeed gmath.Rad
+
+	// ShieldAbsorb reduces the damage that passes through the shield.
+	ShieldAbsorb float64
+
+	// World stats bonuses.
+	MaxCargo      int
+	ScanTimeBonus int
+}
+
+var Modules = []*ModuleDesign{
+	{
+		Name:         "Shield Booster",
+		Description:  "shield blocks more damage",
+		Price:        90,
+		ShieldAbsorb: 0.4,
+	},
+
+	{
+		Name:         "Afterburner",
+		Description:  "higher speed and acceleration",
+		Price:        70,
+		MaxSpeed:     40,
+		Acceleration: 50,
+	},
+
+	{
+		Name:        "Energy Capacitor",
+		Description: "more energy and faster regeneration",
+		Price:       80,
+		MaxEnergy:   40,
+		EnergyRegen: 0.3,
+	},
+
+	{
+		Name:        "Cargo Expander",
+		Description: "more cargo space, but the vessel is slower",
+		Price:       60,
+		MaxCargo:    25,
+		MaxSpeed:    -10,
+	},
+
+	{
+		Name:          "Scanner",
+		Description:   "faster area scouting",
+		Price:         50,
+		ScanTimeBonus: 1,
+	},
+
+	{
+		Name:          "Armor Plating",
+		Description:   "more health, but less maneuverable",
+		Price:         85,
+		MaxHP:         45,
+		MaxSpeed:      -15,
+		RotationSpeed: -0.2,
+	},
+}
+
+func FindModuleDesign(name string) *ModuleDesign {
+	for _, m := range Modules {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// ModuleTotals returns the combined bonuses of all installed modules.
+func (d *VesselDesign) ModuleTotals() ModuleDesign {
+	var total ModuleDesign
+	for _, m := range d.Modules {
+		total.MaxHP += m.MaxHP
+		total.MaxEnergy += m.MaxEnergy
+		total.EnergyRegen += m.EnergyRegen
+		total.MaxSpeed += m.MaxSpeed
+		total.Acceleration += m.Acceleration
+		total.RotationSpeed += m.RotationSpeed
+		total.ShieldAbsorb += m.ShieldAbsorb
+		total.MaxCargo += m.MaxCargo
+		total.ScanTimeBonus += m.ScanTimeBonus
+	}
+	total.ShieldAbsorb = gmath.ClampMax(total.ShieldAbsorb, 0.8)
+	return total
+}
+
+// SellPrice is what shops pay for a used module.
+func (m *ModuleDesign) SellPrice() int {
+	return m.Price / 2
+}
+
+func (p *Player) FreeModuleSlots() int {
+	return p.Hull.Slots - len(p.VesselDesign.Modules)
+}
+
+func (p *Player) HasModule(m *ModuleDesign) bool {
+	return xslices.Contains(p.VesselDesign.Modules, m)
+}
+
+func (p *Player) InstallModule(m *ModuleDesign) {
+	p.VesselDesign.Modules = append(p.VesselDesign.Modules, m)
+	p.MaxCargo += m.MaxCargo
+}
+
+func (p *Player) RemoveModule(m *ModuleDesign) {
+	p.VesselDesign.Modules = xslices.Remove(p.VesselDesign.Modules, m)
+	p.MaxCargo -= m.MaxCargo
+	p.clampCargo()
+}
+
+func (p *Player) ModuleScanTimeBonus() int {
+	return p.VesselDesign.ModuleTotals().ScanTimeBonus
+}
@@ -0,0 +1,198 @@
+package gamedata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RandomEvents are loaded from the embedded data files, see LoadRandomEvents.
+var RandomEvents []*RandomEventDesign
+
+type RandomEventDesign struct {
+	ID string `json:"id"`
+
+	// Weight is a relative probability of this event among all matching events.
+	Weight float64 `json:"weight"`
+
+	// Unique events can happen only once per game.
+	Unique bool `json:"unique"`
+
+	// Text can contain cfmt color tags and {planet} placeholder.
+	Text string `json:"text"`
+
+	Conditions EventConditions `json:"conditions"`
+
+	Choices []*RandomEventChoice `json:"choices"`
+}
+
+type EventConditions struct {
+	Modes []Mode `json:"modes"`
+
+	// Possible values are "allied", "neutral" and "hostile".
+	PlanetFactions []string `json:"planet_factions"`
+	GasGiant       *bool    `json:"gas_giant"`
+
+	MinCargo     int `json:"min_cargo"`
+	MaxCargo     int `json:"max_cargo"`
+	MinFreeCargo int `json:"min_free_cargo"`
+	MinCredits   int `json:"min_credits"`
+	MinFuel      int `json:"min_fuel"`
+
+	Artifacts   []string `json:"artifacts"`
+	NoArtifacts []string `json:"no_artifacts"`
+
+	MinDay int `json:"min_day"`
+	MaxDay int `json:"max_day"`
+
+	MinRank int `json:"min_rank"`
+}
+
+type RandomEventChoice struct {
+	Text string `json:"text"`
+	Time int    `json:"time"`
+
+	// Result is displayed after the choice effects are applied.
+	Result string `json:"result"`
+
+	Conditions EventConditions `json:"conditions"`
+
+	Effects EventEffects `json:"effects"`
+}
+
+type EventEffects struct {
+	Credits    IntRange   `json:"credits"`
+	Fuel       IntRange   `json:"fuel"`
+	Cargo      IntRange   `json:"cargo"`
+	Reputation IntRange   `json:"reputation"`
+	Experience IntRange   `json:"experience"`
+	HP         FloatRange `json:"hp"`
+
+	// Battle is either "pirate" or "hostile".
+	Battle string `json:"battle"`
+}
+
+// IntRange can be decoded from both a single number and a [min, max] pair.
+type IntRange struct {
+	Min int
+	Max int
+}
+
+func (r IntRange) IsZero() bool { return r.Min == 0 && r.Max == 0 }
+
+func (r *IntRange) UnmarshalJSON(data []byte) error {
+	var pair [2]int
+	if err := json.Unmarshal(data, &pair); err == nil {
+		r.Min = pair[0]
+		r.Max = pair[1]
+		return nil
+	}
+	var v int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	r.Min = v
+	r.Max = v
+	return nil
+}
+
+// FloatRange can be decoded from both a single number and a [min, max] pair.
+type FloatRange struct {
+	Min float64
+	Max float64
+}
+
+func (r FloatRange) IsZero() bool { return r.Min == 0 && r.Max == 0 }
+
+func (r *FloatRange) UnmarshalJSON(data []byte) error {
+	var pair [2]float64
+	if err := json.Unmarshal(data, &pair); err == nil {
+		r.Min = pair[0]
+		r.Max = pair[1]
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	r.Min = v
+	r.Max = v
+	return nil
+}
+
+func (m *Mode) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for mode := ModeUnknown; mode <= ModeInTransit; mode++ {
+		if strings.EqualFold(mode.String(), s) {
+			*m = mode
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown mode %q", s)
+}
+
+// LoadRandomEvents decodes every data file and adds its events to the RandomEvents list.
+// Every file is a JSON array of events.
+func LoadRandomEvents(files [][]byte) error {
+	for _, data := range files {
+		var events []*RandomEventDesign
+		if err := json.Unmarshal(data, &events); err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := validateRandomEvent(e); err != nil {
+				return fmt.Errorf("%s: %w", e.ID, err)
+			}
+		}
+		RandomEvents = append(RandomEvents, events...)
+	}
+	return nil
+}
+
+func validateRandomEvent(e *RandomEventDesign) error {
+	if e.ID == "" {
+		return errors.New("empty event id")
+	}
+	if e.Weight <= 0 {
+		return errors.New("weight should be positive")
+	}
+	if len(e.Choices) == 0 {
+		return errors.New("an event should have at least 1 choice")
+	}
+	for _, f := range e.Conditions.PlanetFactions {
+		switch f {
+		case "allied", "neutral", "hostile":
+		default:
+			return fmt.Errorf("unexpected planet faction %q", f)
+		}
+	}
+	if err := validateEventConditions(&e.Conditions); err != nil {
+		return err
+	}
+	for _, c := range e.Choices {
+		if err := validateEventConditions(&c.Conditions); err != nil {
+			return err
+		}
+		switch c.Effects.Battle {
+		case "", "pirate", "hostile":
+		default:
+			return fmt.Errorf("unexpected battle kind %q", c.Effects.Battle)
+		}
+	}
+	return nil
+}
+
+func validateEventConditions(c *EventConditions) error {
+	for _, list := range [][]string{c.Artifacts, c.NoArtifacts} {
+		for _, a := range list {
+			if FindArtifactDesign(a) == nil {
+				return fmt.Errorf("unknown artifact %q", a)
+			}
+		}
+	}
+	return nil
+}
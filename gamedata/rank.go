@@ -1,5 +1,8 @@
 package gamedata
 
+// GovernorMinRank is a rank required to access the allied planets governor office.
+const GovernorMinRank = 4
+
 func GetSalary(exp int) int {
 	return (GetRank(exp) * 3) + 4
 }
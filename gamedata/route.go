@@ -0,0 +1,145 @@
+package gamedata
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+type RouteGoal int
+
+const (
+	RouteMinFuel RouteGoal = iota
+	RouteMinTime
+)
+
+func (g RouteGoal) Name() string {
+	if g == RouteMinTime {
+		return "time"
+	}
+	return "fuel"
+}
+
+// Route is a multi-hop path to a destination planet.
+type Route struct {
+	Hops []RouteHop
+
+	FuelCost int
+	Hours    int
+}
+
+// RouteHop is a single jump of the route.
+type RouteHop struct {
+	Dst       *Planet
+	Hyperjump bool
+	Jump      JumpInfo
+}
+
+// PlanetJumpInfo returns the base in-system jump costs,
+// before the artifact hooks are applied.
+func (p *Player) PlanetJumpInfo(from, to *Planet) JumpInfo {
+	dist := from.Info.MapOffset.DistanceTo(to.Info.MapOffset)
+	return JumpInfo{
+		Dist:     dist,
+		FuelCost: gmath.ClampMin(int(dist*p.FuelUsage*p.JumpFuelMultiplier()), 1),
+		Hours:    int(math.Ceil(dist / p.JumpSpeed)),
+	}
+}
+
+// PlanRoute finds the cheapest path from the player planet to the destination.
+// Depending on the goal, it minimizes either the fuel or the travel time.
+// Hyperjumps are used to reach the other star systems.
+//
+// Every hop is affordable with a full fuel tank, but the total route
+// fuel cost can be higher than that; the player needs to refuel on the way.
+//
+// Returns nil if there is no route.
+func (w *World) PlanRoute(rand *gmath.Rand, dst *Planet, goal RouteGoal) *Route {
+	player := w.Player
+	src := player.Planet
+	if src == dst {
+		return nil
+	}
+
+	type routeNode struct {
+		cost    float64
+		visited bool
+		prev    *Planet
+		hop     RouteHop
+	}
+	nodes := make(map[*Planet]*routeNode, len(w.Planets))
+	for _, p := range w.Planets {
+		nodes[p] = &routeNode{cost: math.MaxFloat64}
+	}
+	nodes[src].cost = 0
+
+	hopCost := func(j JumpInfo) float64 {
+		// The secondary cost component is used to break the ties.
+		if goal == RouteMinTime {
+			return float64(j.Hours) + float64(j.FuelCost)*0.001
+		}
+		return float64(j.FuelCost) + float64(j.Hours)*0.001
+	}
+	relax := func(from, to *Planet, j JumpInfo, hyperjump bool) {
+		player.RunJumpHooks(rand, &j)
+		j.FuelCost = gmath.ClampMin(j.FuelCost, 1)
+		if j.FuelCost > player.MaxFuel {
+			return
+		}
+		cost := nodes[from].cost + hopCost(j)
+		if n := nodes[to]; cost < n.cost {
+			n.cost = cost
+			n.prev = from
+			n.hop = RouteHop{Dst: to, Hyperjump: hyperjump, Jump: j}
+		}
+	}
+
+	for {
+		var current *Planet
+		for _, p := range w.Planets {
+			n := nodes[p]
+			if n.visited || n.cost == math.MaxFloat64 {
+				continue
+			}
+			if current == nil || n.cost < nodes[current].cost {
+				current = p
+			}
+		}
+		if current == nil || current == dst {
+			break
+		}
+		nodes[current].visited = true
+
+		for _, p := range current.System.Planets {
+			if p == current {
+				continue
+			}
+			j := player.PlanetJumpInfo(current, p)
+			if j.Dist > player.MaxJumpDist {
+				continue
+			}
+			relax(current, p, j, false)
+		}
+		for _, s := range w.Systems {
+			if s == current.System {
+				continue
+			}
+			relax(current, s.EntryPlanet(player.Faction), player.HyperjumpInfo(current.System, s), true)
+		}
+	}
+
+	if nodes[dst].prev == nil {
+		return nil
+	}
+	route := &Route{}
+	for p := dst; p != src; p = nodes[p].prev {
+		hop := nodes[p].hop
+		route.Hops = append(route.Hops, hop)
+		route.FuelCost += hop.Jump.FuelCost
+		route.Hours += hop.Jump.Hours
+	}
+	for i, j := 0, len(route.Hops)-1; i < j; i, j = i+1, j-1 {
+		route.Hops[i], route.Hops[j] = route.Hops[j], route.Hops[i]
+	}
+	return route
+}
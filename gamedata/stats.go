@@ -0,0 +1,111 @@
+package gamedata
+
+// NumChallengeTiers is a number of battle challenge levels, see chooseBattleChallenge.
+const NumChallengeTiers = 4
+
+type CreditsSource int
+
+const (
+	CreditsBattle CreditsSource = iota
+	CreditsSalary
+	CreditsQuest
+	CreditsTrade
+	CreditsEquipmentSale
+	CreditsEvent
+	CreditsInterest
+	NumCreditsSources
+)
+
+func (s CreditsSource) Name() string {
+	switch s {
+	case CreditsBattle:
+		return "battles"
+	case CreditsSalary:
+		return "salary"
+	case CreditsQuest:
+		return "quests"
+	case CreditsTrade:
+		return "minerals trade"
+	case CreditsEquipmentSale:
+		return "equipment sales"
+	case CreditsEvent:
+		return "events"
+	case CreditsInterest:
+		return "bank interest"
+	default:
+		return "?"
+	}
+}
+
+// RunStats are collected during the whole game session.
+// They're displayed on the game over screen.
+type RunStats struct {
+	BattlesByChallenge [NumChallengeTiers]int
+	Victories          int
+	Retreats           int
+	PirateRetreats     int
+
+	// Weapons contains the player weapons usage stats, keyed by the weapon name.
+	Weapons map[string]*WeaponStats
+
+	// DamageTaken is keyed by the enemy weapon name.
+	DamageTaken map[string]float64
+
+	CreditsBySource [NumCreditsSources]int
+
+	PlanetsLiberated int
+	ArtifactsFound   int
+	WeaponsBought    int
+}
+
+type WeaponStats struct {
+	Shots       int
+	Hits        int
+	DamageDealt float64
+}
+
+func (s *WeaponStats) Accuracy() float64 {
+	if s.Shots == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Shots)
+}
+
+func (s *RunStats) WeaponStats(w *WeaponDesign) *WeaponStats {
+	if s.Weapons == nil {
+		s.Weapons = make(map[string]*WeaponStats)
+	}
+	ws := s.Weapons[w.Name]
+	if ws == nil {
+		ws = &WeaponStats{}
+		s.Weapons[w.Name] = ws
+	}
+	return ws
+}
+
+func (s *RunStats) AddDamageTaken(w *WeaponDesign, damage float64) {
+	if s.DamageTaken == nil {
+		s.DamageTaken = make(map[string]float64)
+	}
+	s.DamageTaken[w.Name] += damage
+}
+
+func (s *RunStats) TotalBattles() int {
+	total := 0
+	for _, n := range s.BattlesByChallenge {
+		total += n
+	}
+	return total
+}
+
+// AddCredits gives credits to the player and records their source.
+func (w *World) AddCredits(source CreditsSource, amount int) {
+	w.Player.Credits += amount
+	if amount > 0 {
+		w.Stats.CreditsBySource[source] += amount
+	}
+}
+
+func (w *World) DaysSurvived() int {
+	return w.GameTime / 24
+}
@@ -0,0 +1,297 @@
+package gamedata
+
+import (
+	"math"
+
+	"github.com/quasilyte/gmath"
+)
+
+// BaseMaxJumpDist is the starting player jump range.
+// Every star system layout should be traversable with it.
+const BaseMaxJumpDist = 60
+
+// The map panel is a 160x160 image with a sun at its right side.
+const (
+	systemMapMinX = 14
+	systemMapMaxX = 146
+	systemMapMinY = 12
+	systemMapMaxY = 138
+
+	systemSunClearance = 34
+)
+
+var systemSunPos = gmath.Vec{X: 152, Y: 80}
+
+// The map planet sprite frames: gas giants go first.
+const (
+	numGasGiantSprites = 4
+	numRockySprites    = 4
+
+	numStarSprites = 4
+)
+
+type StarSystemKind int
+
+const (
+	StarSystemGenerated StarSystemKind = iota
+	StarSystemClassic
+	NumStarSystemKinds
+)
+
+func (k StarSystemKind) Name() string {
+	switch k {
+	case StarSystemClassic:
+		return "classic"
+	default:
+		return "random"
+	}
+}
+
+// StarSystem is a planets layout the world is created from.
+type StarSystem struct {
+	Planets []*PlanetInfo
+
+	// HomePlanets maps the factions to their starting planet indexes.
+	HomePlanets [NumFactions]int
+
+	Outposts []StarSystemOutpost
+}
+
+// StarSystemOutpost is a small initial garrison on a neutral planet.
+type StarSystemOutpost struct {
+	Planet     int
+	Faction    Faction
+	NumVessels int
+}
+
+// ClassicStarSystem returns the original hand-made solar system layout.
+func ClassicStarSystem() *StarSystem {
+	return &StarSystem{
+		Planets: Planets,
+		HomePlanets: [NumFactions]int{
+			FactionA: 0,
+			FactionB: 2,
+			FactionC: 7,
+		},
+		Outposts: []StarSystemOutpost{
+			{Planet: 1, Faction: FactionB, NumVessels: 2},
+			{Planet: 6, Faction: FactionA, NumVessels: 1},
+		},
+	}
+}
+
+func NewStarSystem(rand *gmath.Rand, kind StarSystemKind, usedNames map[string]bool) *StarSystem {
+	if kind == StarSystemClassic {
+		return ClassicStarSystem()
+	}
+	return GenerateStarSystem(rand, usedNames)
+}
+
+// GenerateStarSystem creates a random planets layout.
+//
+// All planets are reachable from each other by a chain of jumps
+// no longer than BaseMaxJumpDist.
+// Faction homes are placed as far from each other as possible while
+// keeping the amount of nearby neutral planets roughly equal.
+//
+// The usedNames are not used for the new planets and they are added
+// to this set, so several systems can have unique planet names; it can be nil.
+func GenerateStarSystem(rand *gmath.Rand, usedNames map[string]bool) *StarSystem {
+	for attempt := 0; attempt < 20; attempt++ {
+		numPlanets := rand.IntRange(6, 10)
+		positions := generatePlanetPositions(rand, numPlanets)
+		if len(positions) < 6 {
+			continue
+		}
+		homes, ok := pickHomePlanets(positions)
+		if !ok {
+			continue
+		}
+
+		system := &StarSystem{
+			Planets: make([]*PlanetInfo, len(positions)),
+		}
+		names := generatePlanetNames(rand, len(positions), usedNames)
+		for i, pos := range positions {
+			gasGiantChance := 0.15
+			if pos.DistanceTo(systemSunPos) > 90 {
+				gasGiantChance = 0.65
+			}
+			info := &PlanetInfo{
+				Name:      names[i],
+				GasGiant:  rand.Chance(gasGiantChance),
+				MapOffset: pos,
+			}
+			if info.GasGiant {
+				info.MapSprite = rand.IntRange(0, numGasGiantSprites-1)
+			} else {
+				info.MapSprite = numGasGiantSprites + rand.IntRange(0, numRockySprites-1)
+			}
+			system.Planets[i] = info
+		}
+
+		// The home assignment order is random so every faction
+		// can get any part of the map.
+		factions := []Faction{FactionA, FactionB, FactionC}
+		for i := len(factions) - 1; i > 0; i-- {
+			j := rand.IntRange(0, i)
+			factions[i], factions[j] = factions[j], factions[i]
+		}
+		for i, f := range factions {
+			system.HomePlanets[f] = homes[i]
+		}
+
+		// Every faction gets a tiny outpost next to its home.
+		claimed := map[int]bool{}
+		for _, h := range homes {
+			claimed[h] = true
+		}
+		for f := FactionA; f < NumFactions; f++ {
+			home := positions[system.HomePlanets[f]]
+			outpost := -1
+			for i, pos := range positions {
+				if claimed[i] || pos.DistanceTo(home) > BaseMaxJumpDist {
+					continue
+				}
+				if outpost == -1 || pos.DistanceTo(home) < positions[outpost].DistanceTo(home) {
+					outpost = i
+				}
+			}
+			if outpost == -1 {
+				continue
+			}
+			claimed[outpost] = true
+			system.Outposts = append(system.Outposts, StarSystemOutpost{
+				Planet:     outpost,
+				Faction:    f,
+				NumVessels: 1,
+			})
+		}
+
+		return system
+	}
+
+	return ClassicStarSystem()
+}
+
+func generatePlanetPositions(rand *gmath.Rand, numPlanets int) []gmath.Vec {
+	positions := make([]gmath.Vec, 0, numPlanets)
+	for tries := 0; tries < 400 && len(positions) < numPlanets; tries++ {
+		pos := gmath.Vec{
+			X: float64(rand.IntRange(systemMapMinX, systemMapMaxX)),
+			Y: float64(rand.IntRange(systemMapMinY, systemMapMaxY)),
+		}
+		if pos.DistanceTo(systemSunPos) < systemSunClearance {
+			continue
+		}
+		if !canPlacePlanet(positions, pos) {
+			continue
+		}
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+func canPlacePlanet(positions []gmath.Vec, pos gmath.Vec) bool {
+	if len(positions) == 0 {
+		return true
+	}
+	// A new planet should be reachable from the already placed ones;
+	// this keeps the jump graph connected.
+	// The small margin leaves some room for the jump range rounding.
+	connected := false
+	for _, other := range positions {
+		dist := other.DistanceTo(pos)
+		if dist < 26 {
+			return false
+		}
+		// Don't let the planet name labels overlap.
+		if math.Abs(other.X-pos.X) < 44 && math.Abs(other.Y-pos.Y) < 16 {
+			return false
+		}
+		if dist <= BaseMaxJumpDist-4 {
+			connected = true
+		}
+	}
+	return connected
+}
+
+func pickHomePlanets(positions []gmath.Vec) ([3]int, bool) {
+	var best [3]int
+	bestScore := -1.0
+	for a := 0; a < len(positions); a++ {
+		for b := a + 1; b < len(positions); b++ {
+			for c := b + 1; c < len(positions); c++ {
+				homes := [3]int{a, b, c}
+				minDist := math.Min(positions[a].DistanceTo(positions[b]), positions[a].DistanceTo(positions[c]))
+				minDist = math.Min(minDist, positions[b].DistanceTo(positions[c]))
+				// The homes should not be within the jump distance.
+				if minDist <= BaseMaxJumpDist {
+					continue
+				}
+
+				// Every neutral planet belongs to the territory
+				// of the closest home; territories should be balanced.
+				var territory [3]int
+				for i, pos := range positions {
+					if i == a || i == b || i == c {
+						continue
+					}
+					closest := 0
+					for j := 1; j < len(homes); j++ {
+						if pos.DistanceTo(positions[homes[j]]) < pos.DistanceTo(positions[homes[closest]]) {
+							closest = j
+						}
+					}
+					territory[closest]++
+				}
+				minTerritory, maxTerritory := territory[0], territory[0]
+				for _, n := range territory[1:] {
+					if n < minTerritory {
+						minTerritory = n
+					}
+					if n > maxTerritory {
+						maxTerritory = n
+					}
+				}
+				imbalance := maxTerritory - minTerritory
+				if imbalance > 1 {
+					continue
+				}
+
+				score := minDist - 20*float64(imbalance)
+				if score > bestScore {
+					bestScore = score
+					best = homes
+				}
+			}
+		}
+	}
+	return best, bestScore >= 0
+}
+
+var (
+	planetNamePrefixes = []string{
+		"Ar", "Bel", "Cor", "Dra", "El", "Fen", "Gal", "Hel", "Ix", "Kal", "Lor",
+		"Mor", "Nex", "Or", "Pra", "Ryn", "Sel", "Tor", "Ul", "Vex", "Zan",
+	}
+	planetNameSuffixes = []string{
+		"is", "on", "ara", "eus", "ia", "ix", "os", "um", "en", "ar", "ae", "ul",
+	}
+)
+
+func generatePlanetNames(rand *gmath.Rand, n int, used map[string]bool) []string {
+	if used == nil {
+		used = map[string]bool{}
+	}
+	names := make([]string, 0, n)
+	for len(names) < n {
+		name := gmath.RandElem(rand, planetNamePrefixes) + gmath.RandElem(rand, planetNameSuffixes)
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		names = append(names, name)
+	}
+	return names
+}
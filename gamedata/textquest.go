@@ -0,0 +1,248 @@
+package gamedata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TextQuests are loaded from the embedded data files, see LoadTextQuests.
+var TextQuests []*TextQuestDesign
+
+// TextQuestDesign describes a small branching story that is played
+// inside the choice screen. It's a graph of locations connected by
+// transitions; the quest state is a set of named integer params.
+type TextQuestDesign struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+
+	// Weight is a relative probability of this quest among all matching quests.
+	Weight float64 `json:"weight"`
+
+	// Description is displayed when the quest is offered.
+	Description string `json:"description"`
+
+	Conditions EventConditions `json:"conditions"`
+
+	Params []*TextQuestParam `json:"params"`
+
+	// Thresholds are checked after every transition.
+	// The first satisfied threshold ends the quest.
+	Thresholds []*TextQuestThreshold `json:"thresholds"`
+
+	Start     string               `json:"start"`
+	Locations []*TextQuestLocation `json:"locations"`
+
+	Reward  EventEffects `json:"reward"`
+	Penalty EventEffects `json:"penalty"`
+
+	locationByID map[string]*TextQuestLocation
+}
+
+type TextQuestParam struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Value int    `json:"value"`
+
+	// Visible params are displayed below the location text.
+	Visible bool `json:"visible"`
+}
+
+type TextQuestCondition struct {
+	Param string `json:"param"`
+
+	// Op is one of "<", "<=", "==", "!=", ">=", ">".
+	Op string `json:"op"`
+
+	Value int `json:"value"`
+}
+
+type TextQuestThreshold struct {
+	TextQuestCondition
+
+	// Outcome is either "win" or "lose".
+	Outcome string `json:"outcome"`
+
+	Text string `json:"text"`
+}
+
+type TextQuestLocation struct {
+	ID string `json:"id"`
+
+	// Text can contain cfmt color tags, {planet}, {faction} and {param} placeholders.
+	Text string `json:"text"`
+
+	// Ending is either empty, "win" or "lose".
+	// An ending location has no transitions.
+	Ending string `json:"ending"`
+
+	Transitions []*TextQuestTransition `json:"transitions"`
+}
+
+type TextQuestTransition struct {
+	Text string `json:"text"`
+	Time int    `json:"time"`
+	To   string `json:"to"`
+
+	Conditions []TextQuestCondition `json:"conditions"`
+
+	Effects []TextQuestEffect `json:"effects"`
+}
+
+type TextQuestEffect struct {
+	Param string `json:"param"`
+
+	// Op is either "add" or "set".
+	Op string `json:"op"`
+
+	Value IntRange `json:"value"`
+}
+
+// TextQuestState is a text quest that is being played right now.
+type TextQuestState struct {
+	Quest    *TextQuestDesign
+	Location *TextQuestLocation
+	Params   map[string]int
+}
+
+func NewTextQuestState(q *TextQuestDesign) *TextQuestState {
+	params := make(map[string]int, len(q.Params))
+	for _, p := range q.Params {
+		params[p.Name] = p.Value
+	}
+	return &TextQuestState{
+		Quest:    q,
+		Location: q.FindLocation(q.Start),
+		Params:   params,
+	}
+}
+
+func (q *TextQuestDesign) FindLocation(id string) *TextQuestLocation {
+	return q.locationByID[id]
+}
+
+func (c *TextQuestCondition) Check(params map[string]int) bool {
+	v := params[c.Param]
+	switch c.Op {
+	case "<":
+		return v < c.Value
+	case "<=":
+		return v <= c.Value
+	case "==":
+		return v == c.Value
+	case "!=":
+		return v != c.Value
+	case ">=":
+		return v >= c.Value
+	case ">":
+		return v > c.Value
+	default:
+		return false
+	}
+}
+
+// LoadTextQuests decodes every data file and adds its quests to the TextQuests list.
+// Every file is a JSON array of quests.
+func LoadTextQuests(files [][]byte) error {
+	for _, data := range files {
+		var quests []*TextQuestDesign
+		if err := json.Unmarshal(data, &quests); err != nil {
+			return err
+		}
+		for _, q := range quests {
+			if err := initTextQuest(q); err != nil {
+				return fmt.Errorf("%s: %w", q.ID, err)
+			}
+		}
+		TextQuests = append(TextQuests, quests...)
+	}
+	return nil
+}
+
+func initTextQuest(q *TextQuestDesign) error {
+	if q.ID == "" {
+		return errors.New("empty quest id")
+	}
+	if q.Weight <= 0 {
+		return errors.New("weight should be positive")
+	}
+
+	if err := validateEventConditions(&q.Conditions); err != nil {
+		return err
+	}
+
+	params := make(map[string]bool, len(q.Params))
+	for _, p := range q.Params {
+		params[p.Name] = true
+	}
+	validateCondition := func(c *TextQuestCondition) error {
+		if !params[c.Param] {
+			return fmt.Errorf("unknown param %q", c.Param)
+		}
+		switch c.Op {
+		case "<", "<=", "==", "!=", ">=", ">":
+			return nil
+		default:
+			return fmt.Errorf("unexpected condition op %q", c.Op)
+		}
+	}
+
+	q.locationByID = make(map[string]*TextQuestLocation, len(q.Locations))
+	for _, l := range q.Locations {
+		if _, ok := q.locationByID[l.ID]; ok {
+			return fmt.Errorf("duplicated location %q", l.ID)
+		}
+		q.locationByID[l.ID] = l
+	}
+	if q.FindLocation(q.Start) == nil {
+		return fmt.Errorf("start location %q not found", q.Start)
+	}
+
+	for _, th := range q.Thresholds {
+		if err := validateCondition(&th.TextQuestCondition); err != nil {
+			return err
+		}
+		switch th.Outcome {
+		case "win", "lose":
+		default:
+			return fmt.Errorf("unexpected threshold outcome %q", th.Outcome)
+		}
+	}
+
+	for _, l := range q.Locations {
+		switch l.Ending {
+		case "":
+			if len(l.Transitions) == 0 {
+				return fmt.Errorf("%s: a non-ending location should have at least 1 transition", l.ID)
+			}
+		case "win", "lose":
+			if len(l.Transitions) != 0 {
+				return fmt.Errorf("%s: an ending location can't have transitions", l.ID)
+			}
+		default:
+			return fmt.Errorf("%s: unexpected ending %q", l.ID, l.Ending)
+		}
+		for _, t := range l.Transitions {
+			if q.FindLocation(t.To) == nil {
+				return fmt.Errorf("%s: transition to unknown location %q", l.ID, t.To)
+			}
+			for i := range t.Conditions {
+				if err := validateCondition(&t.Conditions[i]); err != nil {
+					return fmt.Errorf("%s: %w", l.ID, err)
+				}
+			}
+			for _, e := range t.Effects {
+				if !params[e.Param] {
+					return fmt.Errorf("%s: unknown param %q", l.ID, e.Param)
+				}
+				switch e.Op {
+				case "add", "set":
+				default:
+					return fmt.Errorf("%s: unexpected effect op %q", l.ID, e.Op)
+				}
+			}
+		}
+	}
+
+	return nil
+}
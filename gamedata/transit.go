@@ -0,0 +1,78 @@
+package gamedata
+
+import (
+	"github.com/quasilyte/gmath"
+)
+
+// Transit is an ongoing jump.
+// The player planet stays the same until the jump is completed.
+type Transit struct {
+	Src *Planet
+	Dst *Planet
+
+	Jump      JumpInfo
+	Hyperjump bool
+
+	HoursLeft int
+
+	// Hostile is set if the jump lane crosses the hostile space.
+	Hostile bool
+
+	// Interrupted is set after an in-transit event;
+	// there can be only one such event per jump.
+	Interrupted bool
+}
+
+// hostileSpaceRadius is a distance from a hostile planet that is considered
+// to be the hostile space.
+const hostileSpaceRadius = 25
+
+func NewTransit(player *Player, dst *Planet, j JumpInfo, hyperjump bool) *Transit {
+	t := &Transit{
+		Src:       player.Planet,
+		Dst:       dst,
+		Jump:      j,
+		Hyperjump: hyperjump,
+		HoursLeft: j.Hours,
+	}
+
+	isHostile := func(p *Planet) bool {
+		return p.Faction != FactionNone && p.Faction != player.Faction
+	}
+	if hyperjump {
+		// The hyperspace itself is neutral, only the ends of the lane matter.
+		t.Hostile = isHostile(t.Src) || isHostile(t.Dst)
+		return t
+	}
+	for _, p := range t.Src.System.Planets {
+		if !isHostile(p) {
+			continue
+		}
+		if segmentDistance(t.Src.Info.MapOffset, t.Dst.Info.MapOffset, p.Info.MapOffset) <= hostileSpaceRadius {
+			t.Hostile = true
+			break
+		}
+	}
+	return t
+}
+
+// EventChance is the probability of an in-transit event during the whole jump.
+// Longer jumps and the hostile space make the events more likely.
+func (t *Transit) EventChance() float64 {
+	chance := 0.05 + t.Jump.Dist*0.002
+	if t.Hostile {
+		chance += 0.15
+	}
+	return gmath.ClampMax(chance, 0.6)
+}
+
+func segmentDistance(a, b, p gmath.Vec) float64 {
+	ab := b.Sub(a)
+	lengthSqr := ab.X*ab.X + ab.Y*ab.Y
+	if lengthSqr == 0 {
+		return p.DistanceTo(a)
+	}
+	ap := p.Sub(a)
+	k := gmath.Clamp((ap.X*ab.X+ap.Y*ab.Y)/lengthSqr, 0, 1)
+	return p.DistanceTo(a.Add(ab.Mulf(k)))
+}
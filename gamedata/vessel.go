@@ -20,10 +20,83 @@ type VesselDesign struct {
 
 	Elite        bool
 	LastDefender bool
+	BountyHunter bool
 	Challenge    int
 
 	RotationSpeed gmath.Rad
 
 	MainWeapon      *WeaponDesign
 	SecondaryWeapon *WeaponDesign
+
+	Modules []*ModuleDesign
+
+	// Ability is only used by the computer pilots.
+	// The player ability comes from the artifacts.
+	Ability *BattleAbility
+}
+
+// Vessel is a concrete ship that is stationed at a planet or travels within a squad.
+// Unlike VesselDesign, it keeps its state between the encounters.
+type Vessel struct {
+	Faction Faction
+
+	// Design is assigned during the first encounter with the player,
+	// this way the vessel challenge matches the player progression.
+	// See InitVesselDesign.
+	Design *VesselDesign
+
+	HP float64 // percentage
+
+	// Veterancy is increased when the vessel survives a planet battle.
+	Veterancy int
+
+	// OriginPlanet is set when a garrison vessel engages the player.
+	// A vessel that survives the battle returns to its origin;
+	// the vessels without any origin are dropped.
+	OriginPlanet *Planet
+
+	// OriginSquad is set when a squad vessel engages the player in transit.
+	OriginSquad *Squad
+}
+
+const MaxVeterancy = 5
+
+func NewVessel(faction Faction) *Vessel {
+	return &Vessel{
+		Faction: faction,
+		HP:      1.0,
+	}
+}
+
+func (v *Vessel) Identified() bool { return v.Design != nil }
+
+// ReturnToOrigin puts the vessel that survived the battle back to where it came from.
+func (v *Vessel) ReturnToOrigin() {
+	switch {
+	case v.OriginPlanet != nil:
+		v.OriginPlanet.AddVessel(v)
+	case v.OriginSquad != nil && v.OriginSquad.Dist <= 0:
+		// The squad has already arrived without this vessel.
+		v.OriginSquad.Dst.AddVessel(v)
+	case v.OriginSquad != nil:
+		v.OriginSquad.Vessels = append(v.OriginSquad.Vessels, v)
+	}
+	v.OriginPlanet = nil
+	v.OriginSquad = nil
+}
+
+func (v *Vessel) AddVeterancy() {
+	if v.Veterancy >= MaxVeterancy {
+		return
+	}
+	v.Veterancy++
+	if v.Design != nil {
+		applyVeterancyBonus(v.Design)
+	}
+}
+
+func applyVeterancyBonus(design *VesselDesign) {
+	design.MaxHP += 8
+	design.MaxEnergy += 5
+	design.EnergyRegen += 0.1
 }
@@ -5,9 +5,21 @@ import (
 	"github.com/quasilyte/vcgj7-game/assets"
 )
 
+// InitVesselDesign assigns a design to the vessel if it doesn't have one yet.
+// The veterancy bonuses that were earned before that moment are applied as well.
+func InitVesselDesign(rand *gmath.Rand, world *World, v *Vessel) {
+	if v.Design != nil {
+		return
+	}
+	v.Design = CreateVesselDesign(rand, world, v.Faction)
+	for i := 0; i < v.Veterancy; i++ {
+		applyVeterancyBonus(v.Design)
+	}
+}
+
 func CreateVesselDesign(rand *gmath.Rand, world *World, faction Faction) *VesselDesign {
 	challenge := chooseBattleChallenge(rand, world)
-	eliteVessel := challenge >= 1 && rand.Chance(0.2)
+	eliteVessel := challenge >= 1 && rand.Chance(world.Difficulty.EliteChance)
 	design := &VesselDesign{
 		Faction:     faction,
 		Challenge:   challenge,
@@ -15,6 +27,9 @@ func CreateVesselDesign(rand *gmath.Rand, world *World, faction Faction) *Vessel
 		MaxEnergy:   75 + float64(rand.IntRange(5, 50)) + float64(challenge*15),
 		EnergyRegen: 1.25 + rand.FloatRange(0.1, 0.3) + (float64(challenge) * 0.2),
 	}
+	if eliteVessel || (challenge >= 3 && rand.Chance(0.3)) {
+		design.Ability = gmath.RandElem(rand, EnemyAbilities)
+	}
 
 	switch challenge {
 	case 0:
@@ -52,6 +67,20 @@ func CreateVesselDesign(rand *gmath.Rand, world *World, faction Faction) *Vessel
 	switch faction {
 	default:
 		panic("unexpected faction")
+	case FactionA: // Alpha
+		// Alpha vessels are only hostile if the player fights for another faction.
+		design.MaxHP = float64(rand.IntRange(85, 115)) + float64(challenge*35)
+		design.MaxSpeed = float64(rand.IntRange(140, 180))
+		design.Acceleration = float64(rand.IntRange(70, 90))
+		design.RotationSpeed = gmath.Rad(rand.FloatRange(1.8, 2.4))
+		if eliteVessel {
+			design.RotationSpeed -= gmath.Rad(rand.FloatRange(0.2, 0.6))
+			design.MaxSpeed -= float64(rand.IntRange(20, 40))
+			design.MaxHP += float64(rand.IntRange(50, 100))
+			design.Image = assets.ImageVesselPlayerElite
+		} else {
+			design.Image = assets.ImageVesselPlayer
+		}
 	case FactionB: // Beta
 		design.MaxHP = float64(rand.IntRange(60, 90)) + float64(challenge*35)
 		design.MaxSpeed = float64(rand.IntRange(180, 240))
@@ -80,21 +109,26 @@ func CreateVesselDesign(rand *gmath.Rand, world *World, faction Faction) *Vessel
 		}
 	}
 
+	design.MaxHP *= world.Difficulty.EnemyHPMultiplier
+	design.MaxEnergy *= world.Difficulty.EnemyEnergyMultiplier
+
 	return design
 }
 
 func chooseBattleChallenge(rand *gmath.Rand, world *World) int {
+	battles := int(float64(world.Player.Battles) * world.Difficulty.ChallengeProgression)
+
 	// Challenges are in 0-3 range.
-	if world.Player.Battles < 3 {
+	if battles < 3 {
 		return 0
 	}
-	if world.Player.Battles < 5 {
+	if battles < 5 {
 		if rand.Chance(0.6) {
 			return 1
 		}
 		return 0
 	}
-	if world.Player.Battles < 9 {
+	if battles < 9 {
 		if rand.Chance(0.6) {
 			return 2
 		}
@@ -103,7 +137,7 @@ func chooseBattleChallenge(rand *gmath.Rand, world *World) int {
 		}
 		return 0
 	}
-	if world.Player.Battles < 15 {
+	if battles < 15 {
 		if rand.Chance(0.4) {
 			return 3
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	resource "github.com/quasilyte/ebitengine-resource"
+	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
 	"github.com/quasilyte/vcgj7-game/assets"
 )
@@ -48,6 +49,67 @@ func FindWeaponDesign(name string) *WeaponDesign {
 	panic(fmt.Sprintf("weapon %q not found", name))
 }
 
+// MaxStoredWeapons is a number of spare weapons that fit into the cargo hold.
+const MaxStoredWeapons = 4
+
+// SellPrice is what shops pay for a used weapon.
+func (w *WeaponDesign) SellPrice() int {
+	return (w.Cost * 4) / 10
+}
+
+// CanAcquireWeapon reports whether a new weapon can be installed.
+// The replaced weapon needs a free spot in the weapon storage.
+func (p *Player) CanAcquireWeapon(w *WeaponDesign) bool {
+	if p.HasWeapon(w) {
+		return false
+	}
+	if p.equippedWeapon(w.Primary) == nil {
+		return true
+	}
+	return len(p.WeaponStorage) < MaxStoredWeapons
+}
+
+// AcquireWeapon installs a new weapon; the replaced weapon goes to the storage.
+func (p *Player) AcquireWeapon(w *WeaponDesign) {
+	if old := p.equippedWeapon(w.Primary); old != nil {
+		p.WeaponStorage = append(p.WeaponStorage, old)
+	}
+	p.setEquippedWeapon(w)
+}
+
+// EquipStoredWeapon swaps the stored weapon with the installed one of the same kind.
+func (p *Player) EquipStoredWeapon(w *WeaponDesign) {
+	p.WeaponStorage = xslices.Remove(p.WeaponStorage, w)
+	if old := p.equippedWeapon(w.Primary); old != nil {
+		p.WeaponStorage = append(p.WeaponStorage, old)
+	}
+	p.setEquippedWeapon(w)
+}
+
+// CanStoreWeapon reports whether a salvaged weapon can be put into the storage.
+func (p *Player) CanStoreWeapon(w *WeaponDesign) bool {
+	return !p.HasWeapon(w) && len(p.WeaponStorage) < MaxStoredWeapons
+}
+
+func (p *Player) RemoveStoredWeapon(w *WeaponDesign) {
+	p.WeaponStorage = xslices.Remove(p.WeaponStorage, w)
+}
+
+func (p *Player) equippedWeapon(primary bool) *WeaponDesign {
+	if primary {
+		return p.VesselDesign.MainWeapon
+	}
+	return p.VesselDesign.SecondaryWeapon
+}
+
+func (p *Player) setEquippedWeapon(w *WeaponDesign) {
+	if w.Primary {
+		p.VesselDesign.MainWeapon = w
+	} else {
+		p.VesselDesign.SecondaryWeapon = w
+	}
+}
+
 var Weapons = []*WeaponDesign{
 	{
 		Name:             "Photon Cannon",
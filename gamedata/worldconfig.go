@@ -0,0 +1,128 @@
+package gamedata
+
+// WorldConfig describes the new game rules.
+type WorldConfig struct {
+	// Seed is used to initialize the random generator before the world is created.
+	Seed int64
+
+	PlayerFaction Faction
+
+	StarSystem StarSystemKind
+
+	// NumSystems is a galaxy size; values below 1 are treated as 1.
+	NumSystems int
+
+	Resources       StartingResources
+	EnemyAggression Aggression
+	Pirates         bool
+	WinCondition    WinCondition
+
+	Difficulty *DifficultyPreset
+}
+
+func DefaultWorldConfig() WorldConfig {
+	return WorldConfig{
+		PlayerFaction:   FactionA,
+		StarSystem:      StarSystemGenerated,
+		NumSystems:      3,
+		Resources:       ResourcesNormal,
+		EnemyAggression: AggressionNormal,
+		Pirates:         true,
+		WinCondition:    WinConquest,
+		Difficulty:      DifficultyPresets[DifficultyNormal],
+	}
+}
+
+type StartingResources int
+
+const (
+	ResourcesLow StartingResources = iota
+	ResourcesNormal
+	ResourcesHigh
+	NumStartingResources
+)
+
+func (r StartingResources) Name() string {
+	switch r {
+	case ResourcesLow:
+		return "low"
+	case ResourcesHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// Multiplier is applied to the starting credits and fuel.
+func (r StartingResources) Multiplier() float64 {
+	switch r {
+	case ResourcesLow:
+		return 0.5
+	case ResourcesHigh:
+		return 2
+	default:
+		return 1
+	}
+}
+
+type Aggression int
+
+const (
+	AggressionLow Aggression = iota
+	AggressionNormal
+	AggressionHigh
+	NumAggressionLevels
+)
+
+func (a Aggression) Name() string {
+	switch a {
+	case AggressionLow:
+		return "low"
+	case AggressionHigh:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// DelaySpeed is how fast the enemy attack and capture delays run out.
+func (a Aggression) DelaySpeed() float64 {
+	switch a {
+	case AggressionLow:
+		return 0.7
+	case AggressionHigh:
+		return 1.5
+	default:
+		return 1
+	}
+}
+
+type WinCondition int
+
+const (
+	// WinConquest requires all other factions to lose their planets.
+	WinConquest WinCondition = iota
+
+	// WinDomination requires the player faction to control a half of the planets
+	// in every star system.
+	WinDomination
+
+	// WinSurvival requires the player faction to hold at least one planet
+	// for WinSurvivalDays days in a row.
+	WinSurvival
+
+	NumWinConditions
+)
+
+const WinSurvivalDays = 30
+
+func (c WinCondition) Name() string {
+	switch c {
+	case WinDomination:
+		return "domination"
+	case WinSurvival:
+		return "survival"
+	default:
+		return "conquest"
+	}
+}
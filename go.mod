@@ -9,6 +9,7 @@ require (
 	github.com/quasilyte/ebitengine-resource v0.5.1-0.20230301215552-afd21c3065ff
 	github.com/quasilyte/ge v0.0.0-20231001193124-a058a3e2d462
 	github.com/quasilyte/gmath v0.0.0-20221217210116-fba37a2e15c7
+	github.com/quasilyte/gsignal v0.0.0-20231010082051-3c00e9ebb4e5
 	golang.org/x/image v0.12.0
 )
 
@@ -18,7 +19,6 @@ require (
 	github.com/jezek/xgb v1.1.0 // indirect
 	github.com/jfreymuth/oggvorbis v1.0.5 // indirect
 	github.com/jfreymuth/vorbis v1.0.2 // indirect
-	github.com/quasilyte/gsignal v0.0.0-20231010082051-3c00e9ebb4e5 // indirect
 	golang.org/x/exp v0.0.0-20230817173708-d852ddb80c63 // indirect
 	golang.org/x/exp/shiny v0.0.0-20230817173708-d852ddb80c63 // indirect
 	golang.org/x/mobile v0.0.0-20230922142353-e2f452493d57 // indirect
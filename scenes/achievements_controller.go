@@ -0,0 +1,88 @@
+package scenes
+
+import (
+	"strings"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/controls"
+	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+	"github.com/quasilyte/vcgj7-game/session"
+	"github.com/quasilyte/vcgj7-game/styles"
+)
+
+type AchievementsController struct {
+	scene *ge.Scene
+	state *session.State
+}
+
+func NewAchievementsController(state *session.State) *AchievementsController {
+	return &AchievementsController{state: state}
+}
+
+func (c *AchievementsController) Init(scene *ge.Scene) {
+	c.scene = scene
+
+	root := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			StretchHorizontal: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(600, 8, nil)
+	root.AddChild(rowContainer)
+
+	rowContainer.AddChild(eui.NewCenteredLabel("Achievements", assets.BitmapFont2))
+
+	lines := make([]string, 0, len(gamedata.Achievements)*2)
+	for _, a := range gamedata.Achievements {
+		status := "[ ]"
+		if c.state.Achievements.Has(a.ID) {
+			status = "[x]"
+		}
+		lines = append(lines, status+" "+a.Name)
+		lines = append(lines, "    "+a.Description)
+	}
+
+	panel := eui.NewPanelWithPadding(c.state.UIResources, 600, 100, widget.NewInsetsSimple(16))
+	rowContainer.AddChild(panel)
+	panel.AddChild(widget.NewText(
+		widget.TextOpts.Text(strings.Join(lines, "\n"), assets.BitmapFont1, styles.ButtonTextColor),
+		widget.TextOpts.MaxWidth(560),
+	))
+
+	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "BACK", func() {
+		c.leave()
+	}))
+
+	initUI(scene, root)
+}
+
+func (c *AchievementsController) Update(delta float64) {
+	if c.state.Input.ActionIsJustPressed(controls.ActionBack) {
+		c.leave()
+	}
+}
+
+func (c *AchievementsController) leave() {
+	c.scene.Context().ChangeScene(NewMainMenuController(c.state))
+}
+
+// announceAchievements returns the newly unlocked achievements text
+// and saves the achievements, if there are any.
+func announceAchievements(scene *ge.Scene, state *session.State) string {
+	world := state.World
+	if len(world.NewAchievements) == 0 {
+		return ""
+	}
+	lines := make([]string, 0, len(world.NewAchievements))
+	for _, a := range world.NewAchievements {
+		lines = append(lines, "Achievement unlocked: "+a.Name)
+	}
+	world.NewAchievements = world.NewAchievements[:0]
+	scene.Context().SaveGameData("achievements", state.Achievements)
+	return strings.Join(lines, "\n")
+}
@@ -1,6 +1,9 @@
 package scenes
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/ge/xslices"
 	"github.com/quasilyte/gmath"
@@ -14,14 +17,18 @@ type BattleController struct {
 	state *session.State
 
 	challenge int
-	enemy     *gamedata.VesselDesign
+	enemy     *gamedata.Vessel
 	runner    *battle.Runner
+
+	// hitsBefore is a weapon stats snapshot that is used
+	// to find out which weapons were used in this battle.
+	hitsBefore map[string]int
 }
 
-func NewBattleController(state *session.State, enemy *gamedata.VesselDesign) *BattleController {
+func NewBattleController(state *session.State, enemy *gamedata.Vessel) *BattleController {
 	return &BattleController{
 		state:     state,
-		challenge: enemy.Challenge,
+		challenge: enemy.Design.Challenge,
 		enemy:     enemy,
 	}
 }
@@ -30,16 +37,36 @@ func (c *BattleController) Init(scene *ge.Scene) {
 	scene.Audio().PauseCurrentMusic()
 	scene.Audio().PlayMusic(assets.AudioMusicCombat)
 
+	c.hitsBefore = make(map[string]int, len(c.state.World.Stats.Weapons))
+	for name, ws := range c.state.World.Stats.Weapons {
+		c.hitsBefore[name] = ws.Hits
+	}
+
 	c.runner = battle.NewRunner(battle.RunnerConfig{
-		Input:  c.state.Input,
-		Enemy:  c.enemy,
-		Player: c.state.World.Player,
+		Input:   c.state.Input,
+		Enemy:   c.enemy.Design,
+		EnemyHP: c.enemy.HP,
+		Player:  c.state.World.Player,
+		Stats:   &c.state.World.Stats,
 	})
 	scene.AddObject(c.runner)
 
 	c.runner.EventBattleOver.Connect(nil, func(results battle.Results) {
 		scene.DelayedCall(2, func() {
 			player := c.state.World.Player
+			c.recordBattle(results)
+
+			if results.Retreat {
+				player.Fuel -= gamedata.RetreatFuelCost
+				player.BattleRewards = gamedata.BattleRewards{Retreat: true}
+				player.VesselHP = results.HP
+				player.Mode = gamedata.ModeAfterCombat
+				// The enemy vessel stays damaged.
+				c.enemy.HP = results.EnemyHP
+				c.enemy.ReturnToOrigin()
+				scene.Context().ChangeScene(NewChoiceController(c.state))
+				return
+			}
 
 			var minExp int
 			var maxExp int
@@ -84,39 +111,62 @@ func (c *BattleController) Init(scene *ge.Scene) {
 				minCargo = 3
 				maxCargo = 25
 			}
-			player.BattleRewards = gamedata.BattleRewards{
-				Victory:    results.Victory,
+			reward := gamedata.Reward{
+				Source:     gamedata.RewardBattle,
 				Experience: scene.Rand().IntRange(minExp, maxExp),
 			}
 			if creditsChance > 0 && scene.Rand().Chance(creditsChance) {
-				player.BattleRewards.Credits = scene.Rand().IntRange(minCredits, maxCredits)
+				reward.Credits = scene.Rand().IntRange(minCredits, maxCredits)
 			}
 			if cargoChance > 0 && scene.Rand().Chance(cargoChance) {
-				player.BattleRewards.Cargo = scene.Rand().IntRange(minCargo, maxCargo)
+				reward.Cargo = scene.Rand().IntRange(minCargo, maxCargo)
 			}
-			if c.enemy.Elite {
-				player.BattleRewards.Experience *= 2
+			if c.enemy.Design.Elite {
+				reward.Experience *= 2
 			}
 
-			if player.BattleRewards.Cargo == 0 && player.BattleRewards.Credits == 0 {
+			if reward.Cargo == 0 && reward.Credits == 0 {
 				if player.Fuel < 70 && scene.Rand().Chance(0.6) {
-					player.BattleRewards.Fuel = scene.Rand().IntRange(2, 10)
+					reward.Fuel = scene.Rand().IntRange(2, 10)
 				}
 			}
 
-			if len(c.state.World.Artifacts) > 0 && c.enemy.Elite {
-				i := gmath.RandIndex(scene.Rand(), c.state.World.Artifacts)
-				a := c.state.World.Artifacts[i]
-				xslices.RemoveAt(c.state.World.Artifacts, i)
-				player.BattleRewards.Artifact = a
+			if c.isPirate() {
+				reward.Credits += scene.Rand().IntRange(40, 90)
+				reward.Cargo += scene.Rand().IntRange(10, 20)
 			}
 
-			if c.enemy.Image == assets.ImageVesselPirate {
-				player.BattleRewards.Credits += scene.Rand().IntRange(40, 90)
-				player.BattleRewards.Cargo += scene.Rand().IntRange(10, 20)
+			player.RunRewardHooks(scene.Rand(), &reward)
+			player.BattleRewards = gamedata.BattleRewards{
+				Victory:    results.Victory,
+				Experience: reward.Experience,
+				Credits:    reward.Credits,
+				Cargo:      reward.Cargo,
+				Fuel:       reward.Fuel,
 			}
 
-			player.BattleRewards.SystemLiberated = c.enemy.LastDefender
+			if results.Victory {
+				if len(c.state.World.Artifacts) > 0 && c.enemy.Design.Elite {
+					player.BattleRewards.Artifact = c.pickArtifact(scene.Rand())
+				}
+				player.BattleRewards.Weapon = c.pickSalvagedWeapon(scene.Rand(), results.HP)
+			} else {
+				// The winner returns to where it came from;
+				// it matters if the player is rescued by the insurance.
+				c.enemy.HP = results.EnemyHP
+				c.enemy.ReturnToOrigin()
+			}
+
+			player.BattleRewards.SystemLiberated = c.enemy.Design.LastDefender
+
+			if results.Victory {
+				c.state.World.RunBattleVictoryAchievements(&gamedata.BattleVictoryInfo{
+					Enemy:       c.enemy,
+					Pirate:      c.isPirate(),
+					DamageTaken: results.DamageTaken,
+					WeaponsUsed: c.weaponsUsed(),
+				})
+			}
 
 			player.VesselHP = results.HP
 			player.Mode = gamedata.ModeAfterCombat
@@ -126,4 +176,95 @@ func (c *BattleController) Init(scene *ge.Scene) {
 	})
 }
 
+// recordBattle adds the battle to the captain's log and to the run stats.
+func (c *BattleController) recordBattle(results battle.Results) {
+	world := c.state.World
+	world.Stats.BattlesByChallenge[gmath.Clamp(c.challenge, 0, gamedata.NumChallengeTiers-1)]++
+	switch {
+	case results.Retreat:
+		world.Stats.Retreats++
+		if c.isPirate() {
+			world.Stats.PirateRetreats++
+		}
+	case results.Victory:
+		world.Stats.Victories++
+	}
+
+	enemyName := "pirate vessel"
+	switch {
+	case c.enemy.Design.BountyHunter:
+		enemyName = "bounty hunter vessel"
+	case c.enemy.Faction != gamedata.FactionNone:
+		enemyName = c.enemy.Faction.Name() + " vessel"
+	}
+	if c.enemy.Design.Elite {
+		enemyName = "elite " + enemyName
+	}
+	if strings.ContainsAny(enemyName[:1], "AEIOUaeiou") {
+		enemyName = "an " + enemyName
+	} else {
+		enemyName = "a " + enemyName
+	}
+	var text string
+	switch {
+	case results.Retreat:
+		text = "Retreated from a battle against " + enemyName
+	case results.Victory:
+		text = "Destroyed " + enemyName
+	default:
+		text = "Lost the vessel in a battle against " + enemyName
+	}
+	world.PushEvent(gamedata.WorldEvent{
+		Category: gamedata.EventBattle,
+		Planet:   world.Player.Planet,
+		Faction:  c.enemy.Faction,
+		Text:     fmt.Sprintf("%s near %s", text, world.Player.Planet.Info.Name),
+	})
+}
+
+// isPirate reports whether the enemy is a regular pirate.
+// The bounty hunters use the pirate vessels, but they're not counted as pirates.
+func (c *BattleController) isPirate() bool {
+	return c.enemy.Design.Image == assets.ImageVesselPirate && !c.enemy.Design.BountyHunter
+}
+
+func (c *BattleController) weaponsUsed() []string {
+	var names []string
+	for name, ws := range c.state.World.Stats.Weapons {
+		if ws.Hits > c.hitsBefore[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c *BattleController) pickArtifact(rand *gmath.Rand) *gamedata.ArtifactDesign {
+	picker := gmath.NewRandPicker[*gamedata.ArtifactDesign](rand)
+	for _, a := range c.state.World.Artifacts {
+		picker.AddOption(a, a.Rarity.DropWeight())
+	}
+	a := picker.Pick()
+	c.state.World.Artifacts = xslices.Remove(c.state.World.Artifacts, a)
+	return a
+}
+
+// pickSalvagedWeapon returns the enemy weapon that survived the battle, if any.
+// Tougher enemies carry weapons that are built to last;
+// a quick victory also leaves less collateral damage.
+func (c *BattleController) pickSalvagedWeapon(rand *gmath.Rand, hp float64) *gamedata.WeaponDesign {
+	design := c.enemy.Design
+	chance := 0.05 + 0.05*float64(c.challenge)
+	if design.Elite {
+		chance += 0.2
+	}
+	chance *= 0.5 + 0.5*hp
+	if !rand.Chance(chance) {
+		return nil
+	}
+	if design.SecondaryWeapon != nil && rand.Bool() {
+		return design.SecondaryWeapon
+	}
+	return design.MainWeapon
+}
+
 func (c *BattleController) Update(delta float64) {}
@@ -21,12 +21,8 @@ type ChoiceController struct {
 	scene *ge.Scene
 	state *session.State
 
-	mapPosMarkerRotation gmath.Rad
-	mapPosMarkerBase     gmath.Vec
-	mapPosMarker         *ge.Sprite
-
-	planetSectorLabels []*ge.Sprite
-	planetSectorTitles []*ge.Label
+	starMap *starMap
+	mapBg   *widget.Graphic
 
 	statusPanelText *widget.Text
 	textPanelText   *widget.Text
@@ -35,6 +31,10 @@ type ChoiceController struct {
 	runner         *worldsim.Runner
 
 	choiceButtons []*choiceButton
+
+	loadoutButton *widget.Button
+	logButton     *widget.Button
+	mapButton     *widget.Button
 }
 
 type choiceButton struct {
@@ -53,36 +53,8 @@ func (c *ChoiceController) Init(scene *ge.Scene) {
 	scene.Audio().PauseCurrentMusic()
 	scene.Audio().PlayMusic(assets.AudioMusicGlobal)
 
-	{
-		c.mapPosMarker = scene.NewSprite(assets.ImageMapLocation)
-		c.mapPosMarker.Pos.Base = &c.mapPosMarkerBase
-		c.mapPosMarker.Rotation = &c.mapPosMarkerRotation
-		scene.AddGraphics(c.mapPosMarker)
-	}
-
-	{
-		c.planetSectorTitles = make([]*ge.Label, len(c.state.World.Planets))
-		c.planetSectorLabels = make([]*ge.Sprite, len(c.state.World.Planets))
-		mapBase := &gmath.Vec{X: 752, Y: 76 - 19}
-		for i, p := range c.state.World.Planets {
-			s := ge.NewSprite(scene.Context())
-			s.Pos.Base = mapBase
-			s.Visible = false
-			s.Pos.Offset = p.Info.MapOffset
-			c.planetSectorLabels[i] = s
-			scene.AddGraphics(s)
-
-			l := ge.NewLabel(assets.BitmapFont1)
-			l.AlignHorizontal = ge.AlignHorizontalCenter
-			l.AlignVertical = ge.AlignVerticalCenter
-			l.Text = strings.TrimPrefix(p.Info.Name, "Planet ")
-			l.Width = 48
-			l.Height = 20
-			l.Pos.Base = mapBase
-			l.Pos.Offset = p.Info.MapOffset.Add(gmath.Vec{X: -23, Y: 12})
-			scene.AddGraphics(l)
-		}
-	}
+	c.starMap = newStarMap(scene, c.state.World, gmath.Vec{X: 752, Y: 76 - 19})
+	c.starMap.SetGalaxyView(false)
 
 	c.runner = worldsim.NewRunner(c.state.World)
 	c.runner.Init(scene)
@@ -94,11 +66,7 @@ func (c *ChoiceController) Init(scene *ge.Scene) {
 }
 
 func (c *ChoiceController) onGameOver(victory bool) {
-	if !victory {
-		c.scene.Context().ChangeScene(NewMainMenuController(c.state))
-	} else {
-		c.scene.Context().ChangeScene(NewVictoryController(c.state))
-	}
+	c.scene.Context().ChangeScene(NewGameOverController(c.state, victory))
 }
 
 func (c *ChoiceController) replaceChoices() {
@@ -117,6 +85,11 @@ func (c *ChoiceController) replaceChoices() {
 	}
 
 	c.textPanelText.Label = result.Text
+	if s := announceAchievements(c.scene, c.state); s != "" {
+		c.textPanelText.Label += "\n\n" + s
+	}
+	c.loadoutButton.GetWidget().Disabled = !c.runner.CanLeave()
+	c.logButton.GetWidget().Disabled = !c.runner.CanLeave()
 }
 
 func (c *ChoiceController) selectChoice(i int) {
@@ -128,6 +101,9 @@ func (c *ChoiceController) selectChoice(i int) {
 	if c.selectedChoice.Mode != gamedata.ModeUnknown {
 		c.state.World.Player.Mode = c.selectedChoice.Mode
 	}
+	if c.selectedChoice.OnStarted != nil {
+		c.selectedChoice.OnStarted()
+	}
 
 	if c.selectedChoice.Time > 0 {
 		if !c.runner.AdvanceTime(c.selectedChoice.Time) {
@@ -143,6 +119,18 @@ func (c *ChoiceController) selectChoice(i int) {
 	c.updateUI()
 }
 
+func (c *ChoiceController) toggleGalaxyView() {
+	galaxyView := !c.starMap.galaxyView
+	c.starMap.SetGalaxyView(galaxyView)
+	if galaxyView {
+		c.mapBg.Image = c.scene.Context().Loader.LoadImage(assets.ImageGalaxyMap).Data
+		c.mapButton.Text().Label = "System map"
+	} else {
+		c.mapBg.Image = c.scene.Context().Loader.LoadImage(assets.ImageSystemMap).Data
+		c.mapButton.Text().Label = "Galaxy map"
+	}
+}
+
 func (c *ChoiceController) onBattleStart(info worldsim.BattleInfo) {
 	c.scene.Context().ChangeScene(NewBattleController(c.state, info.Enemy))
 }
@@ -181,11 +169,44 @@ func (c *ChoiceController) initUI() {
 	picPanel := eui.NewPanelWithPadding(c.state.UIResources, 196, 196, widget.NewInsetsSimple(8))
 	upperGrid.AddChild(picPanel)
 
+	c.loadoutButton = eui.NewButtonWithConfig(c.state.UIResources, eui.ButtonConfig{
+		Text:     "Loadout",
+		MinWidth: 180,
+		OnClick: func() {
+			c.scene.Context().ChangeScene(NewLoadoutController(c.state))
+		},
+		Font: assets.BitmapFont1,
+	})
+	picButtons := eui.NewRowLayoutContainerWithMinWidth(180, 8, nil)
+	picPanel.AddChild(picButtons)
+	picButtons.AddChild(c.loadoutButton)
+
+	c.logButton = eui.NewButtonWithConfig(c.state.UIResources, eui.ButtonConfig{
+		Text:     "Captain's log",
+		MinWidth: 180,
+		OnClick: func() {
+			c.scene.Context().ChangeScene(NewLogController(c.state))
+		},
+		Font: assets.BitmapFont1,
+	})
+	picButtons.AddChild(c.logButton)
+
+	c.mapButton = eui.NewButtonWithConfig(c.state.UIResources, eui.ButtonConfig{
+		Text:     "Galaxy map",
+		MinWidth: 180,
+		OnClick: func() {
+			c.toggleGalaxyView()
+		},
+		Font: assets.BitmapFont1,
+	})
+	c.mapButton.GetWidget().Disabled = len(c.state.World.Systems) < 2
+	picButtons.AddChild(c.mapButton)
+
 	mapPanel := eui.NewPanelWithPadding(c.state.UIResources, 196, 196, widget.NewInsetsSimple(8))
 	upperGrid.AddChild(mapPanel)
 
-	mapBg := eui.NewGraphic(c.state.UIResources, assets.ImageSystemMap)
-	mapPanel.AddChild(mapBg)
+	c.mapBg = eui.NewGraphic(c.state.UIResources, assets.ImageSystemMap)
+	mapPanel.AddChild(c.mapBg)
 
 	lowerGrid := widget.NewContainer(
 		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.RowLayoutData{
@@ -238,7 +259,7 @@ func (c *ChoiceController) initUI() {
 }
 
 func (c *ChoiceController) Update(delta float64) {
-	c.mapPosMarkerRotation += gmath.Rad(2 * delta)
+	c.starMap.Update(delta)
 
 	c.handleInput()
 }
@@ -257,7 +278,7 @@ func (c *ChoiceController) handleInput() {
 }
 
 func (c *ChoiceController) updateUI() {
-	c.mapPosMarkerBase = (gmath.Vec{X: 752, Y: 75 - 19}).Add(c.state.World.Player.Planet.Info.MapOffset)
+	c.starMap.Refresh()
 
 	p := c.state.World.Player
 	{
@@ -266,12 +287,15 @@ func (c *ChoiceController) updateUI() {
 		salary := gamedata.GetSalary(p.Experience) + p.ExtraSalary
 		lines := []string{
 			fmt.Sprintf("Day %d, %02d:00", day, hours),
+			fmt.Sprintf("Location: %s, %s system", p.Planet.Info.Name, p.Planet.System.Name),
 			"",
 			fmt.Sprintf("Combat experience: %d (salary is %d credits/day)", p.Experience, salary),
-			fmt.Sprintf("Credits: %d", p.Credits),
-			fmt.Sprintf("Vessel structure: %d%%", gmath.Clamp(int(100*p.VesselHP), 0, 100)),
+			fmt.Sprintf("Credits: %d (deposit %d, debt %d)", p.Credits, p.Bank.Deposit, p.Bank.Debt),
+			fmt.Sprintf("Reputation: %d", p.Reputation),
+			fmt.Sprintf("Vessel structure: %d%% (%s hull)", gmath.Clamp(int(100*p.VesselHP), 0, 100), p.Hull.Name),
 			fmt.Sprintf("Fuel: %d/%d", p.Fuel, p.MaxFuel),
-			fmt.Sprintf("Cargo: %d/%d", p.Cargo, p.MaxCargo),
+			fmt.Sprintf("Cargo: %d/%d (%d contraband)", p.Cargo+p.Contraband, p.MaxCargo, p.Contraband),
+			fmt.Sprintf("Crew: %d/%d (costs %d credits/day)", p.NumCrew(), gamedata.NumCrewRoles, p.CrewSalary()),
 		}
 		lines = append(lines, "")
 		q := c.state.World.CurrentQuest
@@ -279,26 +303,17 @@ func (c *ChoiceController) updateUI() {
 			lines = append(lines, fmt.Sprintf("Delivery quest destination: %s", q.Receiver.Info.Name))
 		}
 		if len(p.Artifacts) != 0 {
-			lines = append(lines, fmt.Sprintf("Artifacts: %s", strings.Join(p.Artifacts, ", ")))
+			names := make([]string, len(p.Artifacts))
+			for i, a := range p.Artifacts {
+				names[i] = a.Name
+			}
+			lines = append(lines, fmt.Sprintf("Artifacts: %s", strings.Join(names, ", ")))
 		} else {
 			lines = append(lines, "Artifacts: <none>")
 		}
 		c.statusPanelText.Label = strings.Join(lines, "\n")
 	}
 
-	for i, s := range c.planetSectorLabels {
-		p := c.state.World.Planets[i]
-		switch p.Faction {
-		case gamedata.FactionA:
-			s.SetImage(c.scene.Context().Loader.LoadImage(assets.ImageAlliedPlanet))
-			s.Visible = true
-		case gamedata.FactionB, gamedata.FactionC:
-			s.SetImage(c.scene.Context().Loader.LoadImage(assets.ImageHostilePlanet))
-			s.Visible = true
-		default:
-			s.Visible = false
-		}
-	}
 }
 
 func (c *ChoiceController) formatChoiceTime(h int) string {
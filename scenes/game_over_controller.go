@@ -0,0 +1,159 @@
+package scenes
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+	"github.com/quasilyte/vcgj7-game/session"
+	"github.com/quasilyte/vcgj7-game/styles"
+)
+
+// GameOverController is an end-of-run screen with the run summary.
+// It's used for both victory and defeat.
+type GameOverController struct {
+	scene   *ge.Scene
+	state   *session.State
+	victory bool
+}
+
+func NewGameOverController(state *session.State, victory bool) *GameOverController {
+	return &GameOverController{state: state, victory: victory}
+}
+
+func (c *GameOverController) Init(scene *ge.Scene) {
+	c.scene = scene
+
+	scene.Audio().PauseCurrentMusic()
+
+	if c.victory {
+		c.state.World.RunGameVictoryAchievements()
+	}
+	achievementsText := announceAchievements(scene, c.state)
+
+	root := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			StretchHorizontal: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(860, 8, nil)
+	root.AddChild(rowContainer)
+
+	bigFont := assets.BitmapFont3
+
+	title := "Defeat"
+	if c.victory {
+		title = "Victory!"
+	}
+	rowContainer.AddChild(eui.NewCenteredLabel(title, bigFont))
+
+	summaryGrid := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(2),
+			widget.GridLayoutOpts.Stretch([]bool{true, true}, nil),
+			widget.GridLayoutOpts.Spacing(8, 8))))
+	rowContainer.AddChild(summaryGrid)
+	if achievementsText != "" {
+		rowContainer.AddChild(eui.NewCenteredLabel(achievementsText, assets.BitmapFont1))
+	}
+
+	for _, text := range []string{c.generalStatsText(), c.weaponStatsText()} {
+		panel := eui.NewPanelWithPadding(c.state.UIResources, 420, 300, widget.NewInsetsSimple(16))
+		summaryGrid.AddChild(panel)
+		panel.AddChild(widget.NewText(
+			widget.TextOpts.Text(text, assets.BitmapFont1, styles.ButtonTextColor),
+			widget.TextOpts.MaxWidth(390),
+		))
+	}
+
+	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
+
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "BACK TO MENU", func() {
+		scene.Context().ChangeScene(NewMainMenuController(c.state))
+	}))
+
+	if runtime.GOARCH != "wasm" {
+		rowContainer.AddChild(eui.NewButton(c.state.UIResources, "EXIT", func() {
+			os.Exit(0)
+		}))
+	}
+
+	initUI(scene, root)
+}
+
+func (c *GameOverController) generalStatsText() string {
+	world := c.state.World
+	stats := &world.Stats
+
+	lines := make([]string, 0, 24)
+	lines = append(lines, fmt.Sprintf("Days survived: %d", world.DaysSurvived()))
+	lines = append(lines, fmt.Sprintf("Rank: %d", gamedata.GetRank(world.Player.Experience)))
+	lines = append(lines, fmt.Sprintf("Win condition: %s, seed %d", world.Config.WinCondition.Name(), world.Config.Seed))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("Battles: %d (%d won, %d retreats)", stats.TotalBattles(), stats.Victories, stats.Retreats))
+	for tier, n := range stats.BattlesByChallenge {
+		lines = append(lines, fmt.Sprintf("* Tier %d: %d", tier+1, n))
+	}
+	lines = append(lines, fmt.Sprintf("Planets liberated: %d", stats.PlanetsLiberated))
+	lines = append(lines, fmt.Sprintf("Artifacts found: %d", stats.ArtifactsFound))
+	lines = append(lines, "")
+
+	totalCredits := 0
+	for _, n := range stats.CreditsBySource {
+		totalCredits += n
+	}
+	lines = append(lines, fmt.Sprintf("Credits earned: %d", totalCredits))
+	for source, n := range stats.CreditsBySource {
+		if n == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("* %s: %d", gamedata.CreditsSource(source).Name(), n))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *GameOverController) weaponStatsText() string {
+	stats := &c.state.World.Stats
+
+	lines := make([]string, 0, 24)
+	lines = append(lines, "Weapons used:")
+	if len(stats.Weapons) == 0 {
+		lines = append(lines, "<none>")
+	}
+	for _, name := range sortedKeys(stats.Weapons) {
+		ws := stats.Weapons[name]
+		lines = append(lines, fmt.Sprintf("* %s: %d dmg", name, int(ws.DamageDealt)))
+		lines = append(lines, fmt.Sprintf("  %d shots, %d%% accuracy", ws.Shots, int(ws.Accuracy()*100)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "Damage taken:")
+	if len(stats.DamageTaken) == 0 {
+		lines = append(lines, "<none>")
+	}
+	for _, name := range sortedKeys(stats.DamageTaken) {
+		lines = append(lines, fmt.Sprintf("* %s: %d", name, int(stats.DamageTaken[name])))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *GameOverController) Update(delta float64) {}
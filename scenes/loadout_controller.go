@@ -0,0 +1,139 @@
+package scenes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/controls"
+	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+	"github.com/quasilyte/vcgj7-game/session"
+	"github.com/quasilyte/vcgj7-game/styles"
+)
+
+type LoadoutController struct {
+	scene *ge.Scene
+	state *session.State
+}
+
+func NewLoadoutController(state *session.State) *LoadoutController {
+	return &LoadoutController{state: state}
+}
+
+func (c *LoadoutController) Init(scene *ge.Scene) {
+	c.scene = scene
+
+	root := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			StretchHorizontal: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(600, 8, nil)
+	root.AddChild(rowContainer)
+
+	rowContainer.AddChild(eui.NewCenteredLabel("Vessel loadout", assets.BitmapFont2))
+
+	panel := eui.NewPanelWithPadding(c.state.UIResources, 600, 100, widget.NewInsetsSimple(16))
+	rowContainer.AddChild(panel)
+	panel.AddChild(widget.NewText(
+		widget.TextOpts.Text(c.loadoutText(), assets.BitmapFont1, styles.ButtonTextColor),
+		widget.TextOpts.MaxWidth(560),
+	))
+
+	player := c.state.World.Player
+	if player.Mode == gamedata.ModeDocked {
+		actionsGrid := widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewGridLayout(
+				widget.GridLayoutOpts.Columns(2),
+				widget.GridLayoutOpts.Stretch([]bool{true, true}, nil),
+				widget.GridLayoutOpts.Spacing(8, 8))))
+		rowContainer.AddChild(actionsGrid)
+
+		// Spare weapons can be swapped with the installed ones while docked.
+		for _, w := range player.WeaponStorage {
+			w := w
+			actionsGrid.AddChild(eui.NewButton(c.state.UIResources, "Equip "+w.Name, func() {
+				player.EquipStoredWeapon(w)
+				c.scene.Context().ChangeScene(NewLoadoutController(c.state))
+			}))
+		}
+		for _, m := range player.VesselDesign.Modules {
+			m := m
+			label := fmt.Sprintf("Sell %s [%d credits]", m.Name, m.SellPrice())
+			actionsGrid.AddChild(eui.NewButton(c.state.UIResources, label, func() {
+				player.RemoveModule(m)
+				c.state.World.AddCredits(gamedata.CreditsEquipmentSale, m.SellPrice())
+				c.scene.Context().ChangeScene(NewLoadoutController(c.state))
+			}))
+		}
+	}
+
+	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "BACK", func() {
+		c.leave()
+	}))
+
+	initUI(scene, root)
+}
+
+func (c *LoadoutController) loadoutText() string {
+	player := c.state.World.Player
+	design := player.VesselDesign
+	modules := design.ModuleTotals()
+
+	lines := make([]string, 0, 24)
+	lines = append(lines, fmt.Sprintf("Hull: %s (%d/%d module slots used)", player.Hull.Name, len(design.Modules), player.Hull.Slots))
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("Health: %d", int(design.MaxHP+modules.MaxHP)))
+	lines = append(lines, fmt.Sprintf("Energy: %d (%.1f regen)", int(design.MaxEnergy+modules.MaxEnergy), design.EnergyRegen+modules.EnergyRegen))
+	lines = append(lines, fmt.Sprintf("Max speed: %d", int(design.MaxSpeed+modules.MaxSpeed)))
+	lines = append(lines, fmt.Sprintf("Acceleration: %d", int(design.Acceleration+modules.Acceleration)))
+	lines = append(lines, fmt.Sprintf("Rotation speed: %.1f", float64(design.RotationSpeed+modules.RotationSpeed)))
+	if modules.ShieldAbsorb != 0 {
+		lines = append(lines, fmt.Sprintf("Shield absorption bonus: %d%%", int(modules.ShieldAbsorb*100)))
+	}
+	lines = append(lines, fmt.Sprintf("Cargo: %d/%d", player.Cargo, player.MaxCargo))
+	lines = append(lines, fmt.Sprintf("Fuel: %d/%d", player.Fuel, player.MaxFuel))
+	lines = append(lines, fmt.Sprintf("Area scouting time: %d hours", player.ScanTime()))
+
+	lines = append(lines, "")
+	lines = append(lines, "Weapons:")
+	if design.MainWeapon != nil {
+		lines = append(lines, fmt.Sprintf("* %s (primary)", design.MainWeapon.Name))
+	}
+	if design.SecondaryWeapon != nil {
+		lines = append(lines, fmt.Sprintf("* %s (secondary)", design.SecondaryWeapon.Name))
+	}
+	if len(player.WeaponStorage) != 0 {
+		parts := make([]string, len(player.WeaponStorage))
+		for i, w := range player.WeaponStorage {
+			parts[i] = w.Name
+		}
+		lines = append(lines, fmt.Sprintf("Cargo hold (%d/%d): %s", len(player.WeaponStorage), gamedata.MaxStoredWeapons, strings.Join(parts, ", ")))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "Modules:")
+	if len(design.Modules) == 0 {
+		lines = append(lines, "<none>")
+	}
+	for _, m := range design.Modules {
+		lines = append(lines, fmt.Sprintf("* %s, %s", m.Name, m.Description))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c *LoadoutController) Update(delta float64) {
+	if c.state.Input.ActionIsJustPressed(controls.ActionBack) {
+		c.leave()
+	}
+}
+
+func (c *LoadoutController) leave() {
+	c.scene.Context().ChangeScene(NewChoiceController(c.state))
+}
@@ -0,0 +1,182 @@
+package scenes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/controls"
+	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+	"github.com/quasilyte/vcgj7-game/session"
+	"github.com/quasilyte/vcgj7-game/styles"
+)
+
+// logPageLines is a number of log entries that fit into the panel.
+const logPageLines = 14
+
+// LogController is a captain's log scene.
+// It displays the complete events history with optional filters.
+type LogController struct {
+	scene *ge.Scene
+	state *session.State
+
+	// Filter values; 0 means "any", the other values are offset by 1.
+	categoryFilter int
+	planetFilter   int
+	factionFilter  int
+
+	entries []string
+	scroll  int
+
+	text *widget.Text
+}
+
+func NewLogController(state *session.State) *LogController {
+	return &LogController{state: state}
+}
+
+func (c *LogController) Init(scene *ge.Scene) {
+	c.scene = scene
+
+	root := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			StretchHorizontal: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(800, 8, nil)
+	root.AddChild(rowContainer)
+
+	rowContainer.AddChild(eui.NewCenteredLabel("Captain's log", assets.BitmapFont2))
+
+	filtersGrid := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(3),
+			widget.GridLayoutOpts.Stretch([]bool{true, true, true}, nil),
+			widget.GridLayoutOpts.Spacing(8, 8))))
+	rowContainer.AddChild(filtersGrid)
+
+	categoryNames := []string{"any"}
+	for cat := gamedata.WorldEventCategory(0); cat < gamedata.NumEventCategories; cat++ {
+		categoryNames = append(categoryNames, cat.Name())
+	}
+	planetNames := []string{"any"}
+	for _, p := range c.state.World.Planets {
+		planetNames = append(planetNames, p.Info.Name)
+	}
+	factionNames := []string{"any"}
+	for f := gamedata.FactionA; f < gamedata.NumFactions; f++ {
+		factionNames = append(factionNames, f.Name())
+	}
+	filters := []struct {
+		label  string
+		value  *int
+		values []string
+	}{
+		{label: "Type", value: &c.categoryFilter, values: categoryNames},
+		{label: "Planet", value: &c.planetFilter, values: planetNames},
+		{label: "Faction", value: &c.factionFilter, values: factionNames},
+	}
+	for _, f := range filters {
+		filtersGrid.AddChild(eui.NewSelectButton(eui.SelectButtonConfig{
+			Resources:  c.state.UIResources,
+			Input:      c.state.Input,
+			Value:      f.value,
+			Label:      f.label,
+			ValueNames: f.values,
+			OnPressed: func() {
+				c.rebuildEntries()
+				c.updateText()
+			},
+		}))
+	}
+
+	panel := eui.NewPanelWithPadding(c.state.UIResources, 800, 320, widget.NewInsetsSimple(16))
+	rowContainer.AddChild(panel)
+
+	c.text = widget.NewText(
+		widget.TextOpts.Text("", assets.BitmapFont1, styles.ButtonTextColor),
+		widget.TextOpts.MaxWidth(760),
+	)
+	panel.AddChild(c.text)
+
+	scrollGrid := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(3),
+			widget.GridLayoutOpts.Stretch([]bool{true, true, true}, nil),
+			widget.GridLayoutOpts.Spacing(8, 8))))
+	rowContainer.AddChild(scrollGrid)
+	scrollGrid.AddChild(eui.NewButton(c.state.UIResources, "OLDER", func() {
+		c.scrollBy(-logPageLines)
+	}))
+	scrollGrid.AddChild(eui.NewButton(c.state.UIResources, "BACK", func() {
+		c.leave()
+	}))
+	scrollGrid.AddChild(eui.NewButton(c.state.UIResources, "NEWER", func() {
+		c.scrollBy(logPageLines)
+	}))
+
+	initUI(scene, root)
+
+	c.rebuildEntries()
+	c.updateText()
+}
+
+func (c *LogController) rebuildEntries() {
+	world := c.state.World
+	c.entries = c.entries[:0]
+	for _, e := range world.EventLog {
+		if c.categoryFilter != 0 && e.Category != gamedata.WorldEventCategory(c.categoryFilter-1) {
+			continue
+		}
+		if c.planetFilter != 0 && e.Planet != world.Planets[c.planetFilter-1] {
+			continue
+		}
+		if c.factionFilter != 0 && e.Faction != gamedata.FactionA+gamedata.Faction(c.factionFilter-1) {
+			continue
+		}
+		day := (e.Time / 24) + 1
+		hours := e.Time % 24
+		c.entries = append(c.entries, fmt.Sprintf("[Day %d, %02d:00] (%s) %s", day, hours, e.Category.Name(), e.Text))
+	}
+	// Start with the most recent entries.
+	c.scroll = c.maxScroll()
+}
+
+func (c *LogController) maxScroll() int {
+	return gmath.ClampMin(len(c.entries)-logPageLines, 0)
+}
+
+func (c *LogController) scrollBy(delta int) {
+	c.scroll = gmath.Clamp(c.scroll+delta, 0, c.maxScroll())
+	c.updateText()
+}
+
+func (c *LogController) updateText() {
+	if len(c.entries) == 0 {
+		c.text.Label = "No matching entries."
+		return
+	}
+	to := gmath.ClampMax(c.scroll+logPageLines, len(c.entries))
+	header := fmt.Sprintf("Entries %d-%d of %d", c.scroll+1, to, len(c.entries))
+	c.text.Label = header + "\n\n" + strings.Join(c.entries[c.scroll:to], "\n")
+}
+
+func (c *LogController) Update(delta float64) {
+	switch {
+	case c.state.Input.ActionIsJustPressed(controls.ActionBack):
+		c.leave()
+	case c.state.Input.ActionIsJustPressed(controls.ActionScrollUp):
+		c.scrollBy(-1)
+	case c.state.Input.ActionIsJustPressed(controls.ActionScrollDown):
+		c.scrollBy(1)
+	}
+}
+
+func (c *LogController) leave() {
+	c.scene.Context().ChangeScene(NewChoiceController(c.state))
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/quasilyte/ge"
 	"github.com/quasilyte/vcgj7-game/assets"
 	"github.com/quasilyte/vcgj7-game/eui"
-	"github.com/quasilyte/vcgj7-game/gamedata"
 	"github.com/quasilyte/vcgj7-game/session"
 	"github.com/quasilyte/vcgj7-game/styles"
 )
@@ -45,14 +44,17 @@ func (c *MainMenuController) Init(scene *ge.Scene) {
 	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
 
 	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "PLAY", func() {
-		c.state.World = gamedata.NewWorld(scene.Rand())
-		scene.Context().ChangeScene(NewChoiceController(c.state))
+		scene.Context().ChangeScene(NewNewGameController(c.state))
 	}))
 
 	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "SETTINGS", func() {
 		scene.Context().ChangeScene(NewSettingsController(c.state))
 	}))
 
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "ACHIEVEMENTS", func() {
+		scene.Context().ChangeScene(NewAchievementsController(c.state))
+	}))
+
 	b := eui.NewButton(c.state.UIResources, "CREDITS", func() {
 		// TODO
 	})
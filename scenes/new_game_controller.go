@@ -0,0 +1,174 @@
+package scenes
+
+import (
+	"fmt"
+
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/controls"
+	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+	"github.com/quasilyte/vcgj7-game/session"
+	"github.com/quasilyte/vcgj7-game/styles"
+)
+
+// NewGameController is a custom game setup screen.
+type NewGameController struct {
+	scene *ge.Scene
+	state *session.State
+
+	// These are the select button values.
+	faction      int
+	starSystem   int
+	galaxySize   int
+	resources    int
+	aggression   int
+	pirates      int
+	winCondition int
+	seed         int64
+}
+
+func NewNewGameController(state *session.State) *NewGameController {
+	return &NewGameController{state: state}
+}
+
+func (c *NewGameController) Init(scene *ge.Scene) {
+	c.scene = scene
+
+	// Start with the previous game settings, but with a new seed.
+	config := c.state.LastWorldConfig
+	c.faction = int(config.PlayerFaction - gamedata.FactionA)
+	c.starSystem = int(config.StarSystem)
+	c.galaxySize = gmath.Clamp(config.NumSystems, 1, gamedata.MaxGalaxySystems) - 1
+	c.resources = int(config.Resources)
+	c.aggression = int(config.EnemyAggression)
+	if config.Pirates {
+		c.pirates = 1
+	}
+	c.winCondition = int(config.WinCondition)
+	c.seed = c.randomSeed()
+
+	root := widget.NewContainer(
+		widget.ContainerOpts.WidgetOpts(widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+			StretchHorizontal: true,
+		})),
+		widget.ContainerOpts.Layout(widget.NewAnchorLayout()))
+
+	rowContainer := eui.NewRowLayoutContainerWithMinWidth(400, 8, nil)
+	root.AddChild(rowContainer)
+
+	rowContainer.AddChild(eui.NewCenteredLabel("New game", assets.BitmapFont2))
+
+	factionNames := make([]string, 0, gamedata.NumFactions-gamedata.FactionA)
+	for f := gamedata.FactionA; f < gamedata.NumFactions; f++ {
+		factionNames = append(factionNames, f.Name())
+	}
+	starSystemNames := make([]string, gamedata.NumStarSystemKinds)
+	for i := range starSystemNames {
+		starSystemNames[i] = gamedata.StarSystemKind(i).Name()
+	}
+	galaxySizeNames := make([]string, gamedata.MaxGalaxySystems)
+	for i := range galaxySizeNames {
+		galaxySizeNames[i] = fmt.Sprintf("%d systems", i+1)
+	}
+	galaxySizeNames[0] = "1 system"
+	resourcesNames := make([]string, gamedata.NumStartingResources)
+	for i := range resourcesNames {
+		resourcesNames[i] = gamedata.StartingResources(i).Name()
+	}
+	aggressionNames := make([]string, gamedata.NumAggressionLevels)
+	for i := range aggressionNames {
+		aggressionNames[i] = gamedata.Aggression(i).Name()
+	}
+	winConditionNames := make([]string, gamedata.NumWinConditions)
+	for i := range winConditionNames {
+		winConditionNames[i] = gamedata.WinCondition(i).Name()
+	}
+
+	selects := []struct {
+		label  string
+		value  *int
+		values []string
+	}{
+		{label: "Faction", value: &c.faction, values: factionNames},
+		{label: "Home system", value: &c.starSystem, values: starSystemNames},
+		{label: "Galaxy size", value: &c.galaxySize, values: galaxySizeNames},
+		{label: "Starting resources", value: &c.resources, values: resourcesNames},
+		{label: "Enemy aggression", value: &c.aggression, values: aggressionNames},
+		{label: "Pirates", value: &c.pirates, values: []string{"off", "on"}},
+		{label: "Win condition", value: &c.winCondition, values: winConditionNames},
+	}
+	for _, s := range selects {
+		rowContainer.AddChild(eui.NewSelectButton(eui.SelectButtonConfig{
+			Resources:  c.state.UIResources,
+			Input:      c.state.Input,
+			Value:      s.value,
+			Label:      s.label,
+			ValueNames: s.values,
+		}))
+	}
+
+	var seedButton *widget.Button
+	seedButton = eui.NewButton(c.state.UIResources, c.seedLabel(), func() {
+		c.seed = c.randomSeed()
+		seedButton.Text().Label = c.seedLabel()
+	})
+	rowContainer.AddChild(seedButton)
+	if config.Seed != 0 {
+		rowContainer.AddChild(eui.NewButton(c.state.UIResources, "Use previous seed", func() {
+			c.seed = config.Seed
+			seedButton.Text().Label = c.seedLabel()
+		}))
+	}
+
+	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "START", func() {
+		c.startGame()
+	}))
+	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "BACK", func() {
+		c.leave()
+	}))
+
+	initUI(scene, root)
+}
+
+func (c *NewGameController) randomSeed() int64 {
+	return int64(c.scene.Rand().IntRange(1, 999999))
+}
+
+func (c *NewGameController) seedLabel() string {
+	return fmt.Sprintf("Seed: %d (click to reroll)", c.seed)
+}
+
+func (c *NewGameController) startGame() {
+	difficulty := gmath.Clamp(c.state.Settings.Difficulty, 0, int(gamedata.NumDifficulties)-1)
+	config := gamedata.WorldConfig{
+		Seed:            c.seed,
+		PlayerFaction:   gamedata.FactionA + gamedata.Faction(c.faction),
+		StarSystem:      gamedata.StarSystemKind(c.starSystem),
+		NumSystems:      c.galaxySize + 1,
+		Resources:       gamedata.StartingResources(c.resources),
+		EnemyAggression: gamedata.Aggression(c.aggression),
+		Pirates:         c.pirates == 1,
+		WinCondition:    gamedata.WinCondition(c.winCondition),
+		Difficulty:      gamedata.DifficultyPresets[difficulty],
+	}
+	c.state.LastWorldConfig = config
+
+	c.scene.Rand().SetSeed(config.Seed)
+	c.state.World = gamedata.NewWorld(c.scene.Rand(), config)
+	c.state.World.Achievements = &c.state.Achievements
+	c.scene.Context().ChangeScene(NewChoiceController(c.state))
+}
+
+func (c *NewGameController) Update(delta float64) {
+	if c.state.Input.ActionIsJustPressed(controls.ActionBack) {
+		c.leave()
+	}
+}
+
+func (c *NewGameController) leave() {
+	c.scene.Context().ChangeScene(NewMainMenuController(c.state))
+}
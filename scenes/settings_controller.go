@@ -6,6 +6,7 @@ import (
 	"github.com/quasilyte/vcgj7-game/assets"
 	"github.com/quasilyte/vcgj7-game/controls"
 	"github.com/quasilyte/vcgj7-game/eui"
+	"github.com/quasilyte/vcgj7-game/gamedata"
 	"github.com/quasilyte/vcgj7-game/session"
 	"github.com/quasilyte/vcgj7-game/styles"
 )
@@ -63,6 +64,18 @@ func (c *settingsController) initUI(scene *ge.Scene) {
 		},
 	}))
 
+	difficultyNames := make([]string, len(gamedata.DifficultyPresets))
+	for i, preset := range gamedata.DifficultyPresets {
+		difficultyNames[i] = preset.Name
+	}
+	rowContainer.AddChild(eui.NewSelectButton(eui.SelectButtonConfig{
+		Resources:  c.state.UIResources,
+		Input:      c.state.Input,
+		Value:      &c.state.Settings.Difficulty,
+		Label:      "Difficulty",
+		ValueNames: difficultyNames,
+	}))
+
 	rowContainer.AddChild(eui.NewSeparator(nil, styles.TransparentColor))
 	rowContainer.AddChild(eui.NewButton(c.state.UIResources, "OK", func() {
 		c.leave()
@@ -0,0 +1,173 @@
+package scenes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/ge"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/assets"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+// starMap draws the map panel contents on top of its background:
+// either the current star system or the whole galaxy.
+type starMap struct {
+	scene *ge.Scene
+	world *gamedata.World
+
+	base gmath.Vec
+
+	markerRotation gmath.Rad
+	markerBase     gmath.Vec
+
+	galaxyView bool
+	system     *gamedata.System
+
+	graphics     []mapGraphics
+	sectorLabels []*ge.Sprite
+	systemLabels []*ge.Label
+}
+
+type mapGraphics interface {
+	ge.SceneGraphics
+	Dispose()
+}
+
+func newStarMap(scene *ge.Scene, world *gamedata.World, base gmath.Vec) *starMap {
+	return &starMap{
+		scene: scene,
+		world: world,
+		base:  base,
+	}
+}
+
+func (m *starMap) Update(delta float64) {
+	m.markerRotation += gmath.Rad(2 * delta)
+}
+
+func (m *starMap) SetGalaxyView(galaxyView bool) {
+	m.galaxyView = galaxyView
+	m.rebuild()
+	m.Refresh()
+}
+
+// Refresh syncs the map with the current world state.
+func (m *starMap) Refresh() {
+	player := m.world.Player
+	if m.system != player.Planet.System {
+		m.rebuild()
+	}
+
+	if m.galaxyView {
+		m.markerBase = m.base.Add(m.system.GalaxyOffset).Sub(gmath.Vec{Y: 1})
+		for i, s := range m.world.Systems {
+			m.systemLabels[i].Text = fmt.Sprintf("%s\n%d/%d", s.Name, s.CountPlanets(player.Faction), len(s.Planets))
+		}
+		return
+	}
+
+	m.markerBase = m.base.Add(player.Planet.Info.MapOffset).Sub(gmath.Vec{Y: 1})
+	for i, s := range m.sectorLabels {
+		p := m.system.Planets[i]
+		switch p.Faction {
+		case gamedata.FactionNone:
+			s.Visible = false
+		case player.Faction:
+			s.SetImage(m.scene.Context().Loader.LoadImage(assets.ImageAlliedPlanet))
+			s.Visible = true
+		default:
+			s.SetImage(m.scene.Context().Loader.LoadImage(assets.ImageHostilePlanet))
+			s.Visible = true
+		}
+	}
+}
+
+func (m *starMap) rebuild() {
+	for _, g := range m.graphics {
+		g.Dispose()
+	}
+	m.graphics = m.graphics[:0]
+	m.sectorLabels = m.sectorLabels[:0]
+	m.systemLabels = m.systemLabels[:0]
+
+	m.system = m.world.Player.Planet.System
+	if m.galaxyView {
+		m.initGalaxy()
+	} else {
+		m.initSystem()
+	}
+
+	// The position marker goes last, so it's drawn above everything else.
+	marker := m.scene.NewSprite(assets.ImageMapLocation)
+	marker.Pos.Base = &m.markerBase
+	marker.Rotation = &m.markerRotation
+	m.addGraphics(marker)
+}
+
+func (m *starMap) initGalaxy() {
+	for _, s := range m.world.Systems {
+		sprite := m.scene.NewSprite(assets.ImageMapStars)
+		sprite.Pos.Base = &m.base
+		sprite.Pos.Offset = s.GalaxyOffset
+		sprite.FrameOffset.X = float64(s.MapSprite) * sprite.FrameWidth
+		m.addGraphics(sprite)
+
+		l := ge.NewLabel(assets.BitmapFont1)
+		l.AlignHorizontal = ge.AlignHorizontalCenter
+		l.AlignVertical = ge.AlignVerticalCenter
+		l.Width = 64
+		l.Height = 28
+		l.Pos.Base = &m.base
+		l.Pos.Offset = s.GalaxyOffset.Add(gmath.Vec{X: -31, Y: 8})
+		m.systemLabels = append(m.systemLabels, l)
+		m.addGraphics(l)
+	}
+}
+
+func (m *starMap) initSystem() {
+	// The map background has no planets on it, every star system layout
+	// is drawn on top of it: the jump lanes first, then the planets.
+	planets := m.system.Planets
+	for i, p := range planets {
+		for _, other := range planets[i+1:] {
+			if p.Info.MapOffset.DistanceTo(other.Info.MapOffset) > m.world.Player.MaxJumpDist {
+				continue
+			}
+			lane := ge.NewLine(ge.Pos{Base: &m.base, Offset: p.Info.MapOffset}, ge.Pos{Base: &m.base, Offset: other.Info.MapOffset})
+			lane.SetColorScaleRGBA(0x4e, 0x63, 0xcb, 70)
+			m.addGraphics(lane)
+		}
+	}
+	for _, p := range planets {
+		s := m.scene.NewSprite(assets.ImageMapPlanets)
+		s.Pos.Base = &m.base
+		s.Pos.Offset = p.Info.MapOffset
+		s.FrameOffset.X = float64(p.Info.MapSprite) * s.FrameWidth
+		m.addGraphics(s)
+	}
+
+	for _, p := range planets {
+		s := ge.NewSprite(m.scene.Context())
+		s.Pos.Base = &m.base
+		s.Visible = false
+		s.Pos.Offset = p.Info.MapOffset
+		m.sectorLabels = append(m.sectorLabels, s)
+		m.addGraphics(s)
+
+		l := ge.NewLabel(assets.BitmapFont1)
+		l.AlignHorizontal = ge.AlignHorizontalCenter
+		l.AlignVertical = ge.AlignVerticalCenter
+		l.Text = strings.TrimPrefix(p.Info.Name, "Planet ")
+		l.Width = 48
+		l.Height = 20
+		l.Pos.Base = &m.base
+		l.Pos.Offset = p.Info.MapOffset.Add(gmath.Vec{X: -23, Y: 12})
+		m.addGraphics(l)
+	}
+}
+
+func (m *starMap) addGraphics(g mapGraphics) {
+	m.graphics = append(m.graphics, g)
+	m.scene.AddGraphics(g)
+}
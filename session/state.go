@@ -11,9 +11,14 @@ type State struct {
 
 	Settings Settings
 
+	Achievements gamedata.AchievementSet
+
 	Input *input.Handler
 
 	World *gamedata.World
+
+	// LastWorldConfig is used to pre-fill the new game screen.
+	LastWorldConfig gamedata.WorldConfig
 }
 
 type Settings struct {
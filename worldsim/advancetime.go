@@ -11,16 +11,39 @@ import (
 
 func (r *Runner) checkVictory() {
 	others := false
+	numAllied := 0
 	for _, p := range r.world.Planets {
-		if p.Faction == gamedata.FactionNone {
-			continue
-		}
-		if p.Faction != r.world.Player.Faction {
+		switch p.Faction {
+		case gamedata.FactionNone:
+			// Not counted.
+		case r.world.Player.Faction:
+			numAllied++
+		default:
 			others = true
-			break
 		}
 	}
-	if !others {
+
+	if numAllied == 0 {
+		r.world.PlanetlessTime = r.world.GameTime
+	}
+
+	victory := false
+	switch r.world.Config.WinCondition {
+	case gamedata.WinConquest:
+		victory = !others
+	case gamedata.WinDomination:
+		// Every star system should be dominated.
+		victory = true
+		for _, s := range r.world.Systems {
+			if s.CountPlanets(r.world.Player.Faction)*2 < len(s.Planets) {
+				victory = false
+				break
+			}
+		}
+	case gamedata.WinSurvival:
+		victory = numAllied != 0 && r.world.GameTime-r.world.PlanetlessTime >= gamedata.WinSurvivalDays*24
+	}
+	if victory {
 		r.EventGameOver.Emit(true)
 	}
 }
@@ -28,36 +51,35 @@ func (r *Runner) checkVictory() {
 func (r *Runner) AdvanceTime(hours int) bool {
 	player := r.world.Player
 
-	canRegen := false
-	switch player.Mode {
-	case gamedata.ModeJustEntered, gamedata.ModeOrbiting, gamedata.ModeScavenging, gamedata.ModeSneaking:
-		canRegen = true
-	}
+	wait := r.wait
+	r.wait = nil
 
 	for i := 0; i < hours; i++ {
 		r.world.GameTime++
 		r.checkVictory()
 
-		if r.world.GameTime%24 == 0 {
-			salary := gamedata.GetSalary(player.Experience) + player.ExtraSalary
-			player.Credits += salary
+		if player.Mode == gamedata.ModeJump && player.Transit != nil {
+			player.Transit.HoursLeft--
 		}
 
-		if player.HasArtifact("Fuel Generator") && canRegen {
-			if r.scene.Rand().Chance(0.6) {
-				player.Fuel = gmath.ClampMax(player.Fuel+1, player.MaxFuel)
-			}
-		}
-		if player.HasArtifact("Repair Bots") && canRegen {
-			if r.scene.Rand().Chance(0.8) {
-				player.VesselHP = gmath.ClampMax(player.VesselHP+0.02, 1.0)
-			}
+		if r.world.GameTime%24 == 0 {
+			salary := int(float64(gamedata.GetSalary(player.Experience)+player.ExtraSalary) * r.world.Difficulty.SalaryMultiplier)
+			r.world.AddCredits(gamedata.CreditsSalary, salary)
+			r.payCrew()
+			r.processBank()
 		}
+		r.healCrew()
+
+		player.RunHourHooks(r.scene.Rand())
+		r.world.RunHourAchievements()
 
 		// One in-game hour is simulated during 1 second in delta time terms.
 		if r.processEncounters() {
 			return false
 		}
+		if r.processRandomEvents() {
+			return false
+		}
 		for j := 0; j < 5; j++ {
 			if r.updateWorld(0.2) {
 				return false
@@ -66,11 +88,50 @@ func (r *Runner) AdvanceTime(hours int) bool {
 		for _, p := range r.world.Planets {
 			r.processPlanetBattles(p)
 		}
+		if wait != nil {
+			if reason := r.checkWaitInterrupt(wait); reason != "" {
+				r.eventInfo = eventInfo{kind: eventMessage, text: reason}
+				return false
+			}
+		}
 	}
 	return true
 }
 
-func (r *Runner) makePirate() *gamedata.VesselDesign {
+func (r *Runner) payCrew() {
+	player := r.world.Player
+	for i, c := range player.Crew {
+		if c == nil {
+			continue
+		}
+		if player.Credits < c.Salary() {
+			// Unpaid crew members leave the vessel.
+			player.Crew[i] = nil
+			r.world.PushEvent(gamedata.WorldEvent{
+				Category: gamedata.EventCrew,
+				Text:     fmt.Sprintf("%s %s left the crew because of the unpaid salary", gamedata.CrewRole(i).Name(), c.Name),
+			})
+			continue
+		}
+		player.Credits -= c.Salary()
+	}
+}
+
+func (r *Runner) healCrew() {
+	player := r.world.Player
+	for _, c := range player.Crew {
+		if c == nil || !c.IsInjured() {
+			continue
+		}
+		c.InjuryTime--
+		if player.Mode == gamedata.ModeDocked {
+			// Medical facilities speed up the recovery.
+			c.InjuryTime = gmath.ClampMin(c.InjuryTime-1, 0)
+		}
+	}
+}
+
+func (r *Runner) makePirate() *gamedata.Vessel {
 	pirate := &gamedata.VesselDesign{
 		Image:         assets.ImageVesselPirate,
 		MaxHP:         float64(r.scene.Rand().IntRange(120, 150) + (r.world.PirateSeq * 50)),
@@ -86,13 +147,16 @@ func (r *Runner) makePirate() *gamedata.VesselDesign {
 	} else {
 		pirate.MainWeapon = gamedata.FindWeaponDesign("Trident")
 	}
-	return pirate
+	return &gamedata.Vessel{
+		Design: pirate,
+		HP:     1.0,
+	}
 }
 
 func (r *Runner) processEncounters() bool {
 	player := r.world.Player
 
-	if r.world.NextPirateDelay == 0 && r.world.PirateSeq < 3 {
+	if r.world.Config.Pirates && r.world.NextPirateDelay == 0 && r.world.PirateSeq < 3 {
 		if player.VesselHP >= 0.8 && player.Battles >= 4 {
 			r.world.NextPirateDelay = r.scene.Rand().FloatRange(600, 1200)
 			r.eventInfo = eventInfo{
@@ -105,6 +169,22 @@ func (r *Runner) processEncounters() bool {
 		r.world.NextPirateDelay = r.scene.Rand().FloatRange(20, 40)
 	}
 
+	if player.CustomsPending {
+		player.CustomsPending = false
+		// Leaving the planet right away avoids the customs.
+		if player.Mode != gamedata.ModeJump && r.processCustoms() {
+			return true
+		}
+	}
+
+	if player.Mode == gamedata.ModeJump && player.Transit != nil {
+		return r.processTransitEvents()
+	}
+
+	if r.maybeBountyHunter() {
+		return true
+	}
+
 	planet := player.Planet
 
 	encounterChance := 0.0
@@ -126,11 +206,12 @@ func (r *Runner) processEncounters() bool {
 	case planet.Faction == gamedata.FactionNone:
 		encounterChance *= 0.65
 	}
+	encounterChance *= r.world.Difficulty.EncounterMultiplier
 	if encounterChance > 0 && r.scene.Rand().Chance(encounterChance) {
 		// If there is any hostile vessels around here, the battle will start.
 		r.encounterOptions = r.encounterOptions[:0]
-		for i, num := range planet.VesselsByFaction {
-			if num == 0 {
+		for i, vessels := range planet.VesselsByFaction {
+			if len(vessels) == 0 {
 				continue
 			}
 			f := gamedata.Faction(i)
@@ -141,7 +222,9 @@ func (r *Runner) processEncounters() bool {
 		}
 		if len(r.encounterOptions) != 0 {
 			enemyFaction := gmath.RandElem(r.scene.Rand(), r.encounterOptions)
-			enemy := gamedata.CreateVesselDesign(r.scene.Rand(), r.world, enemyFaction)
+			enemy := gmath.RandElem(r.scene.Rand(), planet.VesselsByFaction[enemyFaction])
+			enemy.OriginPlanet = planet
+			gamedata.InitVesselDesign(r.scene.Rand(), r.world, enemy)
 			r.eventInfo = eventInfo{
 				kind:  eventBattleInterrupt,
 				enemy: enemy,
@@ -155,8 +238,8 @@ func (r *Runner) processEncounters() bool {
 
 func (r *Runner) processPlanetBattles(p *gamedata.Planet) {
 	r.planetFactions = r.planetFactions[:0]
-	for i, num := range p.VesselsByFaction {
-		if num == 0 {
+	for i, vessels := range p.VesselsByFaction {
+		if len(vessels) == 0 {
 			continue
 		}
 		f := gamedata.Faction(i)
@@ -172,42 +255,85 @@ func (r *Runner) processPlanetBattles(p *gamedata.Planet) {
 	gmath.Shuffle(r.scene.Rand(), r.planetFactions)
 	faction1 := r.planetFactions[0]
 	faction2 := r.planetFactions[1]
+	vessel1 := gmath.RandElem(r.scene.Rand(), p.VesselsByFaction[faction1])
+	vessel2 := gmath.RandElem(r.scene.Rand(), p.VesselsByFaction[faction2])
 	loser := faction1
 	winner := faction2
-	if r.scene.Rand().Bool() {
+	loserVessel := vessel1
+	winnerVessel := vessel2
+	// Damaged vessels are more likely to lose, veterans are more likely to win.
+	strength1 := vesselStrength(vessel1)
+	strength2 := vesselStrength(vessel2)
+	switch p.Faction {
+	case faction1:
+		strength1 *= p.DefenseMultiplier()
+	case faction2:
+		strength2 *= p.DefenseMultiplier()
+	}
+	if r.scene.Rand().Chance(strength1 / (strength1 + strength2)) {
 		loser, winner = winner, loser
+		loserVessel, winnerVessel = winnerVessel, loserVessel
+	}
+	p.RemoveVessel(loserVessel)
+	winnerVessel.HP = gmath.ClampMin(winnerVessel.HP-r.scene.Rand().FloatRange(0.05, 0.4), 0.1)
+	if r.scene.Rand().Chance(0.3) {
+		winnerVessel.AddVeterancy()
 	}
-	p.VesselsByFaction[loser]--
 
-	if p.Faction == loser && p.VesselsByFaction[loser] == 0 {
+	if p.Faction == loser && p.NumVessels(loser) == 0 {
+		e := gamedata.WorldEvent{
+			Category: gamedata.EventLoss,
+			Planet:   p,
+			Faction:  loser,
+		}
 		if p.Faction == r.world.Player.Faction {
-			r.world.PushEvent(fmt.Sprintf("We lost control over %s", p.Info.Name))
+			e.Text = fmt.Sprintf("We lost control over %s", p.Info.Name)
 		} else {
 			if winner == r.world.Player.Faction {
-				r.world.PushEvent(fmt.Sprintf("%s is liberated from the enemy forces", p.Info.Name))
+				r.world.Stats.PlanetsLiberated++
+				e.Text = fmt.Sprintf("%s is liberated from the enemy forces", p.Info.Name)
 			} else {
-				r.world.PushEvent(fmt.Sprintf("%s lost %s to %s", winner.Name(), p.Info.Name, loser.Name()))
+				e.Text = fmt.Sprintf("%s lost %s to %s", winner.Name(), p.Info.Name, loser.Name())
 			}
 		}
+		r.world.PushEvent(e)
 		p.Faction = gamedata.FactionNone
 		p.VesselProduction = false
 		p.VesselProductionTime = 0
+		p.ResetManagement()
 	}
 }
 
+func vesselStrength(v *gamedata.Vessel) float64 {
+	return gmath.ClampMin(v.HP, 0.1) * (1.0 + 0.15*float64(v.Veterancy))
+}
+
 func (r *Runner) processPlanetActions(p *gamedata.Planet) {
 	if p.AttackDelay == 0 {
-		numVessels := p.VesselsByFaction[p.Faction]
-		if numVessels < 10 && r.scene.Rand().Chance(0.8) {
+		if p.Stance == gamedata.StanceDefensive {
+			// Defensive planets keep their garrison at home.
+			p.AttackDelay = r.scene.Rand().FloatRange(60, 100)
+			return
+		}
+		offensive := p.Stance == gamedata.StanceOffensive
+		numVessels := p.NumVessels(p.Faction)
+		holdChance := 0.8
+		if offensive {
+			holdChance = 0.4
+		}
+		if numVessels < 10 && r.scene.Rand().Chance(holdChance) {
 			p.AttackDelay = r.scene.Rand().FloatRange(60, 100)
 			return
 		}
-		if numVessels < 20 && r.scene.Rand().Chance(0.5) {
+		if !offensive && numVessels < 20 && r.scene.Rand().Chance(0.5) {
 			p.AttackDelay = r.scene.Rand().FloatRange(20, 150)
 			return
 		}
 		if r.tryFactionAttack(p) {
 			p.AttackDelay = r.scene.Rand().FloatRange(70, 300)
+			if offensive {
+				p.AttackDelay *= 0.5
+			}
 			return
 		}
 		p.AttackDelay = r.scene.Rand().FloatRange(20, 40)
@@ -215,8 +341,12 @@ func (r *Runner) processPlanetActions(p *gamedata.Planet) {
 	}
 
 	if p.CaptureDelay == 0 {
-		numVessels := p.VesselsByFaction[p.Faction]
-		if numVessels < 10 && r.scene.Rand().Chance(0.9) {
+		numVessels := p.NumVessels(p.Faction)
+		minVessels := 10
+		if p.Stance == gamedata.StanceDefensive {
+			minVessels = 15
+		}
+		if numVessels < minVessels && r.scene.Rand().Chance(0.9) {
 			p.CaptureDelay = r.scene.Rand().FloatRange(60, 100)
 			return
 		}
@@ -230,7 +360,7 @@ func (r *Runner) processPlanetActions(p *gamedata.Planet) {
 }
 
 func (r *Runner) tryFactionAttack(planet *gamedata.Planet) bool {
-	if planet.VesselsByFaction[planet.Faction] <= r.scene.Rand().IntRange(5, 15) {
+	if planet.NumVessels(planet.Faction) <= r.scene.Rand().IntRange(5, 15) {
 		return false
 	}
 
@@ -240,12 +370,12 @@ func (r *Runner) tryFactionAttack(planet *gamedata.Planet) bool {
 		largeSquad = true
 		attackVessels *= 2
 	}
-	if attackVessels > planet.VesselsByFaction[planet.Faction] {
-		attackVessels = planet.VesselsByFaction[planet.Faction] - r.scene.Rand().IntRange(2, 4)
+	if attackVessels > planet.NumVessels(planet.Faction) {
+		attackVessels = planet.NumVessels(planet.Faction) - r.scene.Rand().IntRange(2, 4)
 	}
 
 	targetPlanet := randIterate(r.scene.Rand(), r.world.Planets, func(p *gamedata.Planet) bool {
-		if p.Faction == planet.Faction || p.Faction == gamedata.FactionNone {
+		if p.Faction == planet.Faction || p.Faction == gamedata.FactionNone || p.System != planet.System {
 			return false
 		}
 		dist := p.Info.MapOffset.DistanceTo(planet.Info.MapOffset)
@@ -260,10 +390,20 @@ func (r *Runner) tryFactionAttack(planet *gamedata.Planet) bool {
 
 	if planet.Faction != r.world.Player.Faction {
 		if largeSquad {
-			r.world.PushEvent(fmt.Sprintf("%s (controlled by %s) dispatched a large group of vessels", planet.Faction.Name(), planet.Info.Name))
+			r.world.PushEvent(gamedata.WorldEvent{
+				Category: gamedata.EventAttack,
+				Planet:   planet,
+				Faction:  planet.Faction,
+				Text:     fmt.Sprintf("%s (controlled by %s) dispatched a large group of vessels", planet.Info.Name, planet.Faction.Name()),
+			})
 		}
 	} else {
-		r.world.PushEvent(fmt.Sprintf("Allies start an attack operation on %s", targetPlanet.Info.Name))
+		r.world.PushEvent(gamedata.WorldEvent{
+			Category: gamedata.EventAttack,
+			Planet:   targetPlanet,
+			Faction:  planet.Faction,
+			Text:     fmt.Sprintf("Allies start an attack operation on %s", targetPlanet.Info.Name),
+		})
 	}
 
 	speed := r.scene.Rand().FloatRange(5, 9)
@@ -272,26 +412,26 @@ func (r *Runner) tryFactionAttack(planet *gamedata.Planet) bool {
 	}
 
 	squad := &gamedata.Squad{
-		NumVessels: attackVessels,
-		Faction:    planet.Faction,
-		Speed:      speed,
-		Dist:       planet.Info.MapOffset.DistanceTo(targetPlanet.Info.MapOffset),
-		Dst:        targetPlanet,
+		Vessels: planet.TakeVessels(planet.Faction, attackVessels),
+		Faction: planet.Faction,
+		Speed:   speed,
+		Dist:    planet.Info.MapOffset.DistanceTo(targetPlanet.Info.MapOffset),
+		Src:     planet,
+		Dst:     targetPlanet,
 	}
 	r.world.Squads = append(r.world.Squads, squad)
-	planet.VesselsByFaction[planet.Faction] -= attackVessels
 	return true
 }
 
 func (r *Runner) tryFactionCapture(planet *gamedata.Planet) bool {
-	if planet.VesselsByFaction[planet.Faction] <= r.scene.Rand().IntRange(5, 10) {
+	if planet.NumVessels(planet.Faction) <= r.scene.Rand().IntRange(5, 10) {
 		return false
 	}
 
 	attackVessels := r.scene.Rand().IntRange(1, 3)
 
 	targetPlanet := randIterate(r.scene.Rand(), r.world.Planets, func(p *gamedata.Planet) bool {
-		if p.Faction != gamedata.FactionNone {
+		if p.Faction != gamedata.FactionNone || p.System != planet.System {
 			return false
 		}
 		dist := p.Info.MapOffset.DistanceTo(planet.Info.MapOffset)
@@ -306,14 +446,14 @@ func (r *Runner) tryFactionCapture(planet *gamedata.Planet) bool {
 
 	speed := r.scene.Rand().FloatRange(6, 11)
 	squad := &gamedata.Squad{
-		NumVessels: attackVessels,
-		Faction:    planet.Faction,
-		Speed:      speed,
-		Dist:       planet.Info.MapOffset.DistanceTo(targetPlanet.Info.MapOffset),
-		Dst:        targetPlanet,
+		Vessels: planet.TakeVessels(planet.Faction, attackVessels),
+		Faction: planet.Faction,
+		Speed:   speed,
+		Dist:    planet.Info.MapOffset.DistanceTo(targetPlanet.Info.MapOffset),
+		Src:     planet,
+		Dst:     targetPlanet,
 	}
 	r.world.Squads = append(r.world.Squads, squad)
-	planet.VesselsByFaction[planet.Faction] -= attackVessels
 	return true
 }
 
@@ -341,6 +481,7 @@ func (r *Runner) maybeRollQuest() {
 
 func (r *Runner) updateWorld(delta float64) bool {
 	r.world.NextPirateDelay = gmath.ClampMin(r.world.NextPirateDelay-delta, 0)
+	r.world.RandomEventDelay = gmath.ClampMin(r.world.RandomEventDelay-delta, 0)
 	r.world.QuestRerollDelay = gmath.ClampMin(r.world.QuestRerollDelay-delta, 0)
 	r.world.UpgradeRerollDelay = gmath.ClampMin(r.world.UpgradeRerollDelay-delta, 0)
 	r.world.NextUpgradeDelay = gmath.ClampMin(r.world.NextUpgradeDelay-delta, 0)
@@ -372,7 +513,9 @@ func (r *Runner) updateWorld(delta float64) bool {
 	for _, squad := range r.world.Squads {
 		squad.Dist -= delta * squad.Speed
 		if squad.Dist <= 0 {
-			squad.Dst.VesselsByFaction[squad.Faction] += squad.NumVessels
+			for _, v := range squad.Vessels {
+				squad.Dst.AddVessel(v)
+			}
 			continue
 		}
 		squads = append(squads, squad)
@@ -384,8 +527,12 @@ func (r *Runner) updateWorld(delta float64) bool {
 		p.WeaponsRerollDelay = gmath.ClampMin(p.WeaponsRerollDelay-delta, 0)
 		p.ShopSwapDelay = gmath.ClampMin(p.ShopSwapDelay-delta, 0)
 		p.ResourceGenDelay = gmath.ClampMin(p.ResourceGenDelay-delta, 0)
-		p.AttackDelay = gmath.ClampMin(p.AttackDelay-delta, 0)
-		p.CaptureDelay = gmath.ClampMin(p.CaptureDelay-delta, 0)
+		actionDelta := delta
+		if p.Faction != r.world.Player.Faction {
+			actionDelta *= r.world.Config.EnemyAggression.DelaySpeed()
+		}
+		p.AttackDelay = gmath.ClampMin(p.AttackDelay-actionDelta, 0)
+		p.CaptureDelay = gmath.ClampMin(p.CaptureDelay-actionDelta, 0)
 
 		if p.Faction == gamedata.FactionNone {
 			for i := range p.InfluenceByFaction {
@@ -393,15 +540,15 @@ func (r *Runner) updateWorld(delta float64) bool {
 			}
 			faction := gamedata.FactionNone
 			numFactions := 0
-			for i, num := range p.VesselsByFaction {
-				if num == 0 {
+			for i, vessels := range p.VesselsByFaction {
+				if len(vessels) == 0 {
 					continue
 				}
 				numFactions++
 				faction = gamedata.Faction(i)
 			}
 			if numFactions == 1 {
-				numVessels := p.VesselsByFaction[faction]
+				numVessels := p.NumVessels(faction)
 				v := math.Log(float64(numVessels)) + 1.0
 				p.InfluenceByFaction[faction] += v * delta
 				// 1 vessels (v=1.000) capture in 30.000 days
@@ -417,7 +564,12 @@ func (r *Runner) updateWorld(delta float64) bool {
 					p.Faction = faction
 					p.AttackDelay = r.scene.Rand().FloatRange(100, 500)
 					p.CaptureDelay = r.scene.Rand().FloatRange(400, 600)
-					r.world.PushEvent(fmt.Sprintf("%s established control over %s", faction.Name(), p.Info.Name))
+					r.world.PushEvent(gamedata.WorldEvent{
+						Category: gamedata.EventCapture,
+						Planet:   p,
+						Faction:  faction,
+						Text:     fmt.Sprintf("%s established control over %s", faction.Name(), p.Info.Name),
+					})
 				}
 			}
 		}
@@ -425,6 +577,8 @@ func (r *Runner) updateWorld(delta float64) bool {
 		if p.WeaponsRerollDelay == 0 {
 			p.WeaponsRerollDelay = r.scene.Rand().FloatRange(28, 40)
 			r.rerollWeaponsSelection(p)
+			r.rerollHullsSelection(p)
+			r.rerollModulesSelection(p)
 		}
 
 		if p.ShopSwapDelay == 0 {
@@ -456,19 +610,27 @@ func (r *Runner) updateWorld(delta float64) bool {
 			continue
 		}
 
+		// Garrison vessels are slowly repaired by their planet.
+		for _, v := range p.VesselsByFaction[p.Faction] {
+			v.HP = gmath.ClampMax(v.HP+0.01*delta, 1.0)
+		}
+
 		r.processPlanetActions(p)
 
 		if p.VesselProduction {
 			p.VesselProductionTime = gmath.ClampMin(p.VesselProductionTime-delta, 0)
 			if p.VesselProductionTime == 0 {
-				p.VesselsByFaction[p.Faction]++
+				p.AddVessel(gamedata.NewVessel(p.Faction))
 				p.VesselProduction = false
 			}
 		} else {
-			if p.MineralDeposit >= 50 && p.VesselsByFaction[p.Faction] < p.GarrisonLimit {
+			if p.MineralDeposit >= 50 && p.NumVessels(p.Faction) < p.GarrisonLimit {
 				cost := r.scene.Rand().IntRange(20, 50)
 				p.MineralDeposit -= cost
-				p.VesselProductionTime = float64(r.scene.Rand().IntRange(40, 100))
+				p.VesselProductionTime = float64(r.scene.Rand().IntRange(40, 100)) * p.ProductionTimeMultiplier()
+				if p.Faction != r.world.Player.Faction {
+					p.VesselProductionTime *= r.world.Difficulty.EnemyProductionMultiplier
+				}
 				p.VesselProduction = true
 			}
 		}
@@ -476,6 +638,18 @@ func (r *Runner) updateWorld(delta float64) bool {
 	return false
 }
 
+func (r *Runner) rerollHullsSelection(p *gamedata.Planet) {
+	p.HullsAvailable = p.HullsAvailable[:0]
+	for _, h := range gamedata.Hulls {
+		if !h.SoldBy(p.Faction) {
+			continue
+		}
+		if r.scene.Rand().Chance(0.7) {
+			p.HullsAvailable = append(p.HullsAvailable, h.Name)
+		}
+	}
+}
+
 func (r *Runner) rerollWeaponsSelection(p *gamedata.Planet) {
 	p.WeaponsAvailable = p.WeaponsAvailable[:0]
 	if r.scene.Rand().Chance(0.05) {
@@ -489,3 +663,14 @@ func (r *Runner) rerollWeaponsSelection(p *gamedata.Planet) {
 		p.WeaponsAvailable = append(p.WeaponsAvailable, w.Name)
 	}
 }
+
+func (r *Runner) rerollModulesSelection(p *gamedata.Planet) {
+	p.ModulesAvailable = p.ModulesAvailable[:0]
+	numModules := r.scene.Rand().IntRange(0, 2)
+	modules := make([]*gamedata.ModuleDesign, len(gamedata.Modules))
+	copy(modules, gamedata.Modules)
+	gmath.Shuffle(r.scene.Rand(), modules)
+	for _, m := range modules[:numModules] {
+		p.ModulesAvailable = append(p.ModulesAvailable, m.Name)
+	}
+}
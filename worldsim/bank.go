@@ -0,0 +1,163 @@
+package worldsim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) bankChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	account := &player.Bank
+
+	lines := make([]string, 0, 8)
+	lines = append(lines, cfmt("The <p>%s</> bank branch serves all allied captains.", planet.Info.Name))
+	lines = append(lines, "")
+	lines = append(lines, cfmt("Deposit: <y>%d</> credits (%d%% daily)", account.Deposit, int(gamedata.DepositDailyInterest*100)))
+	if account.Debt > 0 {
+		lines = append(lines, cfmt("Debt: <r>%d</> credits (%d%% daily)", account.Debt, int(gamedata.LoanDailyInterest*100)))
+		if account.IsOverdue() {
+			lines = append(lines, cfmt("The debt is <r>overdue</> by %d days!", account.OverdueDays()))
+		} else {
+			lines = append(lines, fmt.Sprintf("The debt is due in %d days.", gamedata.LoanTermDays-account.DebtDays+1))
+		}
+	}
+	loanAmount := gmath.ClampMax(player.MaxLoan()-account.Debt, gamedata.LoanStep)
+	if loanAmount > 0 {
+		lines = append(lines, cfmt("Available loan: <y>%d</> credits.", loanAmount))
+	} else {
+		lines = append(lines, "The bank is not ready to give you a loan.")
+	}
+
+	bankAction := func(text string, f func()) {
+		r.choices = append(r.choices, Choice{
+			Text: text,
+			OnResolved: func() gamedata.Mode {
+				f()
+				r.eventInfo = eventInfo{kind: eventBank}
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	if loanAmount >= 10 && !account.IsOverdue() {
+		bankAction(fmt.Sprintf("Take a loan [+%d credits]", loanAmount), func() {
+			account.Debt += loanAmount
+			player.Credits += loanAmount
+			r.world.PushEvent(gamedata.WorldEvent{
+				Category: gamedata.EventBank,
+				Planet:   planet,
+				Text:     fmt.Sprintf("Took a loan of %d credits at %s", loanAmount, planet.Info.Name),
+			})
+		})
+	}
+	if account.Debt > 0 && player.Credits > 0 {
+		amount := gmath.ClampMax(player.Credits, account.Debt)
+		bankAction(fmt.Sprintf("Repay the debt [%d credits]", amount), func() {
+			player.Credits -= account.Repay(amount)
+		})
+	}
+	if player.Credits >= 50 {
+		bankAction("Deposit 50 credits", func() {
+			player.Credits -= 50
+			account.Deposit += 50
+		})
+	}
+	if player.Credits > 50 {
+		amount := player.Credits
+		bankAction(fmt.Sprintf("Deposit all credits [%d credits]", amount), func() {
+			player.Credits -= amount
+			account.Deposit += amount
+		})
+	}
+	if account.Deposit > 0 {
+		amount := account.Deposit
+		bankAction(fmt.Sprintf("Withdraw the deposit [%d credits]", amount), func() {
+			account.Deposit -= amount
+			player.Credits += amount
+		})
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Leave the bank",
+		OnResolved: func() gamedata.Mode {
+			r.eventInfo = eventInfo{kind: eventDistrict}
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+// processBank is called at the daily tick.
+func (r *Runner) processBank() {
+	player := r.world.Player
+	account := &player.Bank
+
+	income := account.ApplyDailyInterest()
+	r.world.Stats.CreditsBySource[gamedata.CreditsInterest] += income
+
+	if !account.IsOverdue() {
+		return
+	}
+
+	if account.OverdueDays() == 1 {
+		r.world.PushEvent(gamedata.WorldEvent{
+			Category: gamedata.EventBank,
+			Text:     fmt.Sprintf("The debt of %d credits is overdue", account.Debt),
+		})
+	}
+
+	// The deposit is the first thing that goes to cover the debt.
+	if account.Deposit > 0 {
+		seized := account.Repay(account.Deposit)
+		account.Deposit -= seized
+		r.world.PushEvent(gamedata.WorldEvent{
+			Category: gamedata.EventBank,
+			Text:     fmt.Sprintf("The bank seized %d deposit credits to cover the overdue debt", seized),
+		})
+	}
+	if account.Debt == 0 {
+		return
+	}
+
+	// The debtors are not welcome among the allies.
+	player.Reputation = gmath.ClampMin(player.Reputation-2, gamedata.MinReputation)
+
+	// The cargo can be seized only while the vessel is in the docks.
+	if player.Mode == gamedata.ModeDocked && player.Cargo > 0 {
+		n := gmath.ClampMax(player.Cargo, (account.Debt+gamedata.SeizedCargoPrice-1)/gamedata.SeizedCargoPrice)
+		player.Cargo -= n
+		account.Repay(n * gamedata.SeizedCargoPrice)
+		r.world.PushEvent(gamedata.WorldEvent{
+			Category: gamedata.EventBank,
+			Planet:   player.Planet,
+			Text:     fmt.Sprintf("The bank seized %d cargo units at %s", n, player.Planet.Info.Name),
+		})
+	}
+}
+
+// maybeBountyHunter sends a bounty hunter after the player with an overdue debt.
+// The longer the debt is overdue, the more likely it is.
+func (r *Runner) maybeBountyHunter() bool {
+	player := r.world.Player
+	if !player.Mode.IsIdleInSpace() || !player.Bank.IsOverdue() {
+		return false
+	}
+	chance := gmath.ClampMax(0.01*float64(player.Bank.OverdueDays()), 0.1)
+	if !r.scene.Rand().Chance(chance) {
+		return false
+	}
+	hunter := r.makePirate()
+	hunter.Design.MaxHP *= 1.2
+	hunter.Design.MaxEnergy *= 1.2
+	hunter.Design.BountyHunter = true
+	r.eventInfo = eventInfo{
+		kind:         eventBattleInterrupt,
+		enemy:        hunter,
+		bountyHunter: true,
+	}
+	return true
+}
@@ -9,6 +9,10 @@ type Choice struct {
 	Text       string
 	Mode       gamedata.Mode // In-process mode
 	OnResolved func() gamedata.Mode
+
+	// OnStarted is called before the time is advanced; can be nil.
+	// Note that OnResolved is not called if the choice is interrupted.
+	OnStarted func()
 }
 
 const MaxChoices = 7
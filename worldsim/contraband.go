@@ -0,0 +1,169 @@
+package worldsim
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+// processCustoms rolls the customs scan on arrival.
+func (r *Runner) processCustoms() bool {
+	player := r.world.Player
+	planet := player.Planet
+
+	chance := r.world.CustomsScanChance(planet)
+	if chance == 0 || !r.scene.Rand().Chance(chance) {
+		return false
+	}
+
+	// The hostile customs can open fire without any negotiations.
+	if planet.Faction != player.Faction && r.scene.Rand().Bool() {
+		if enemy := r.customsPatrolVessel(); enemy != nil {
+			r.eventInfo = eventInfo{
+				kind:  eventBattleInterrupt,
+				enemy: enemy,
+				text:  cfmt("The <r>%s</> customs patrol detected the contraband in your cargo hold.", planet.Faction.Name()),
+			}
+			return true
+		}
+	}
+
+	r.eventInfo = eventInfo{kind: eventCustoms}
+	return true
+}
+
+func (r *Runner) customsPatrolVessel() *gamedata.Vessel {
+	planet := r.world.Player.Planet
+	vessels := planet.VesselsByFaction[planet.Faction]
+	if len(vessels) == 0 {
+		return nil
+	}
+	enemy := gmath.RandElem(r.scene.Rand(), vessels)
+	enemy.OriginPlanet = planet
+	gamedata.InitVesselDesign(r.scene.Rand(), r.world, enemy)
+	return enemy
+}
+
+func (r *Runner) customsChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	allied := planet.Faction == player.Faction
+
+	fine := r.world.Price(player.Contraband * gamedata.ContrabandFine)
+	if !allied {
+		fine *= 2
+	}
+	// The allied customs report the smugglers to the command.
+	reputationDelta := func(v int) {
+		if allied {
+			player.Reputation = gmath.ClampMin(player.Reputation-v, gamedata.MinReputation)
+		}
+	}
+
+	lines := make([]string, 0, 4)
+	lines = append(lines, cfmt("A <p>%s</> customs patrol scans your vessel and finds <r>%d</> units of contraband.", planet.Faction.Name(), player.Contraband))
+	lines = append(lines, "")
+	lines = append(lines, cfmt("The officer demands a fine of <y>%d</> credits. Otherwise, the contraband will be confiscated.", fine))
+
+	if player.Credits >= fine {
+		r.choices = append(r.choices, Choice{
+			Text: fmt.Sprintf("Pay the fine [%d credits]", fine),
+			OnResolved: func() gamedata.Mode {
+				player.Credits -= fine
+				reputationDelta(2)
+				return gamedata.ModeOrbiting
+			},
+		})
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Surrender the contraband",
+		OnResolved: func() gamedata.Mode {
+			player.Contraband = 0
+			reputationDelta(5)
+			return gamedata.ModeOrbiting
+		},
+	})
+	// Only the hostile customs can be fought; the allied garrison is not an enemy.
+	if !allied {
+		if enemy := r.customsPatrolVessel(); enemy != nil {
+			r.choices = append(r.choices, Choice{
+				Text: "Refuse the inspection",
+				OnResolved: func() gamedata.Mode {
+					r.eventInfo = eventInfo{
+						kind:  eventBattle,
+						enemy: enemy,
+						text:  "The customs patrol vessel moves to intercept you.",
+					}
+					return gamedata.ModeOrbiting
+				},
+			})
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) blackMarketChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	market := planet.Market
+	price := market.ContrabandPrice * r.world.Difficulty.PriceMultiplier
+
+	lines := make([]string, 0, 6)
+	if market.ContrabandLegal {
+		lines = append(lines, cfmt("The contraband trade is legal at <p>%s</>. It costs <y>%.1f</> credits per unit here.", planet.Info.Name, price))
+		lines = append(lines, "Some planets pay much more for it, but their customs don't like the smugglers.")
+	} else {
+		lines = append(lines, cfmt("The contraband is illegal at <p>%s</>, but the local dealers pay <y>%.1f</> credits per unit.", planet.Info.Name, market.ContrabandPrice))
+	}
+	lines = append(lines, "")
+	lines = append(lines, cfmt("Contraband: <y>%d</> units, free cargo space: %d", player.Contraband, player.FreeCargoSpace()))
+
+	if market.ContrabandLegal {
+		maxAmount := gmath.ClampMax(player.FreeCargoSpace(), int(float64(player.Credits)/price))
+		for _, amount := range marketAmounts(maxAmount, 5, 10) {
+			amount := amount
+			cost := int(math.Ceil(float64(amount) * price))
+			r.choices = append(r.choices, Choice{
+				Text: fmt.Sprintf("Buy %d contraband [%d credits]", amount, cost),
+				OnResolved: func() gamedata.Mode {
+					player.Credits -= cost
+					player.Contraband += amount
+					r.eventInfo = eventInfo{kind: eventBlackMarket}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+	}
+
+	if player.Contraband > 0 {
+		// Selling the contraband doesn't depend on the difficulty prices.
+		sellPrice := market.ContrabandPrice
+		if market.ContrabandLegal {
+			sellPrice *= 0.8
+		}
+		total := int(float64(player.Contraband) * sellPrice)
+		r.choices = append(r.choices, Choice{
+			Text: fmt.Sprintf("Sell all contraband [%d credits]", total),
+			OnResolved: func() gamedata.Mode {
+				player.Contraband = 0
+				r.world.AddCredits(gamedata.CreditsTrade, total)
+				r.eventInfo = eventInfo{kind: eventBlackMarket}
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Leave the black market",
+		OnResolved: func() gamedata.Mode {
+			r.eventInfo = eventInfo{kind: eventDistrict}
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
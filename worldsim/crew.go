@@ -0,0 +1,82 @@
+package worldsim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) pickInjuredCrewMember() *gamedata.CrewMember {
+	crew := make([]*gamedata.CrewMember, 0, len(r.world.Player.Crew))
+	for _, c := range r.world.Player.Crew {
+		if c != nil {
+			crew = append(crew, c)
+		}
+	}
+	if len(crew) == 0 {
+		return nil
+	}
+	return gmath.RandElem(r.scene.Rand(), crew)
+}
+
+func formatCrewMember(c *gamedata.CrewMember) string {
+	s := cfmt("%s <y>%s</> (skill <g>%d</>, salary <y>%d</>)", c.Role.Name(), c.Name, c.Level, c.Salary())
+	if c.IsInjured() {
+		s += cfmt(" <r>injured</> for %d hours", c.InjuryTime)
+	}
+	return s
+}
+
+func (r *Runner) crewAgencyChoices() string {
+	player := r.world.Player
+
+	lines := make([]string, 0, 12)
+	lines = append(lines, "The recruitment office has a few pilots looking for a job.")
+	lines = append(lines, "")
+	lines = append(lines, "Your crew:")
+	if player.NumCrew() == 0 {
+		lines = append(lines, "  <nobody>")
+	}
+	for _, c := range player.Crew {
+		if c != nil {
+			lines = append(lines, "  "+formatCrewMember(c))
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "Candidates:")
+	rank := gamedata.GetRank(player.Experience)
+	numCandidates := r.scene.Rand().IntRange(1, 3)
+	for i := 0; i < numCandidates; i++ {
+		role := gamedata.CrewRole(r.scene.Rand().IntRange(0, int(gamedata.NumCrewRoles)-1))
+		level := rank/3 + r.scene.Rand().IntRange(0, 1)
+		candidate := gamedata.NewCrewMember(r.scene.Rand(), role, level)
+		price := candidate.HirePrice()
+		lines = append(lines, "  "+formatCrewMember(candidate))
+		if player.Credits < price {
+			continue
+		}
+		r.choices = append(r.choices, Choice{
+			Text: fmt.Sprintf("Hire %s %s [%d credits]", strings.ToLower(role.Name()), candidate.Name, price),
+			Time: 1,
+			OnResolved: func() gamedata.Mode {
+				player.Credits -= price
+				player.Crew[candidate.Role] = candidate
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Leave recruitment office",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeDocked
+		},
+	})
+
+	lines = append(lines, "")
+	lines = append(lines, "A new crew member replaces the one with the same role.")
+	return strings.Join(lines, "\n")
+}
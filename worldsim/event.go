@@ -22,16 +22,37 @@ const (
 	eventFuelScavenge
 	eventMineralsHunt
 	eventScanArea
+	eventNavigation
+	eventHyperjump
+	eventRoutePlanner
+	eventWait
+
+	eventTransitDistress
+	eventTransitMalfunction
+	eventTransitAnomaly
 
 	eventTakeQuest
 	eventCompleteQuest
 	eventNews
 	eventBuyFuel
+	eventRepair
 	eventUpgradeLab
 	eventWeaponShop
+	eventWeaponSell
 	eventShipyard
 	eventWorkshop
 	eventSellMinerals
+	eventGovernorOffice
+	eventDistrict
+	eventBar
+	eventCrewAgency
+	eventBank
+	eventInsurance
+	eventBlackMarket
+	eventCustoms
+
+	eventRandom
+	eventMessage
 )
 
 func (r *Runner) afterBattleChoices() string {
@@ -40,7 +61,22 @@ func (r *Runner) afterBattleChoices() string {
 	reward := player.BattleRewards
 	player.BattleRewards = gamedata.BattleRewards{}
 
+	if reward.Retreat {
+		r.choices = append(r.choices, Choice{
+			Text: "Done",
+			OnResolved: func() gamedata.Mode {
+				return gamedata.ModeOrbiting
+			},
+		})
+		return cfmt("You managed to escape the battle.\n\nThe enemy vessel will remember this encounter.")
+	}
+
 	if !reward.Victory {
+		if player.Insured {
+			if s := r.insuranceRescueChoices(); s != "" {
+				return s
+			}
+		}
 		r.choices = append(r.choices, Choice{
 			Text: "The great ranger's life has come to an end",
 			OnResolved: func() gamedata.Mode {
@@ -51,21 +87,45 @@ func (r *Runner) afterBattleChoices() string {
 		return "Your vessel was destroyed in battle."
 	}
 
+	// A salvaged weapon that doesn't fit into the storage is sold right away.
+	weaponStored := reward.Weapon != nil && player.CanStoreWeapon(reward.Weapon)
+
 	r.choices = append(r.choices, Choice{
 		Text: "Done",
 		OnResolved: func() gamedata.Mode {
 			player.Experience += reward.Experience
-			player.Credits += reward.Credits
+			r.world.AddCredits(gamedata.CreditsBattle, reward.Credits)
+			player.TrainCrew(gamedata.CrewGunner, 2)
 			player.LoadCargo(reward.Cargo)
 			player.Fuel = gmath.ClampMax(player.Fuel+reward.Fuel, player.MaxFuel)
-			if reward.Artifact != "" {
+			if reward.Artifact != nil {
 				player.Artifacts = append(player.Artifacts, reward.Artifact)
+				r.world.Stats.ArtifactsFound++
+			}
+			if reward.Weapon != nil {
+				if weaponStored {
+					player.WeaponStorage = append(player.WeaponStorage, reward.Weapon)
+				} else {
+					r.world.AddCredits(gamedata.CreditsBattle, reward.Weapon.SellPrice())
+				}
 			}
 			if reward.SystemLiberated {
+				planet := player.Planet
+				r.world.Stats.PlanetsLiberated++
+				r.world.PushEvent(gamedata.WorldEvent{
+					Category: gamedata.EventLoss,
+					Planet:   planet,
+					Faction:  planet.Faction,
+					Text:     fmt.Sprintf("%s lost control over %s", planet.Faction.Name(), planet.Info.Name),
+				})
+				planet.Faction = gamedata.FactionNone
+				planet.VesselProduction = false
+				planet.VesselProductionTime = 0
+				planet.ResetManagement()
 				if player.ExtraSalary < 20 {
 					player.ExtraSalary += 3
 				} else {
-					player.Credits += 30
+					r.world.AddCredits(gamedata.CreditsBattle, 30)
 				}
 			}
 			return gamedata.ModeOrbiting
@@ -86,21 +146,16 @@ func (r *Runner) afterBattleChoices() string {
 	if reward.Fuel != 0 {
 		lines = append(lines, cfmt("Recovered <y>%d</> fuel units.", reward.Fuel))
 	}
-	if reward.Artifact != "" {
-		desc := ""
-		switch reward.Artifact {
-		case "Fuel Generator":
-			desc = "Acquired <g>Fuel Generator</> artifact."
-		case "Repair Bots":
-			desc = "Acquired <g>Repair Bots</> artifact."
-		case "Scantide":
-			desc = "Acquired <g>Scantide artifact</> (makes scanning faster)."
-		case "Lucky Charm":
-			desc = "Acquired <g>Lucky Charm</> artifact."
-		case "Jumper":
-			desc = "Acquired <g>Jumper</> artifact (makes jumps cost less fuel)."
-		}
-		lines = append(lines, cfmt(desc))
+	if reward.Artifact != nil {
+		a := reward.Artifact
+		lines = append(lines, cfmt("Acquired %s <g>%s</> artifact (%s).", a.Rarity.Name(), a.Name, a.Description))
+	}
+	if reward.Weapon != nil {
+		if weaponStored {
+			lines = append(lines, cfmt("Salvaged %s, it's stored in the cargo hold.", formatWeapon(reward.Weapon)))
+		} else {
+			lines = append(lines, cfmt("Salvaged %s and sold the scrap for <y>%d</> credits.", formatWeapon(reward.Weapon), reward.Weapon.SellPrice()))
+		}
 	}
 
 	if reward.SystemLiberated {
@@ -120,6 +175,24 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 	planet := player.Planet
 
 	switch event.kind {
+	case eventRandom:
+		return r.randomEventChoices(event.randomEvent)
+
+	case eventMessage:
+		restMode := gamedata.ModeOrbiting
+		if player.Mode == gamedata.ModeDocked {
+			restMode = gamedata.ModeDocked
+		} else if player.Transit != nil {
+			restMode = gamedata.ModeInTransit
+		}
+		r.choices = append(r.choices, Choice{
+			Text: "Done",
+			OnResolved: func() gamedata.Mode {
+				return restMode
+			},
+		})
+		return event.text
+
 	case eventCompleteQuest:
 		q := r.world.CurrentQuest
 		r.world.CurrentQuest = nil
@@ -127,8 +200,13 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			Text: "Done",
 			OnResolved: func() gamedata.Mode {
 				r.world.QuestRerollDelay = float64(r.scene.Rand().IntRange(60, 90))
-				player.Credits += q.CreditsReward
+				r.world.AddCredits(gamedata.CreditsQuest, q.CreditsReward)
 				player.Experience += q.ExpReward
+				r.world.PushEvent(gamedata.WorldEvent{
+					Category: gamedata.EventQuest,
+					Planet:   planet,
+					Text:     fmt.Sprintf("Delivered the cargo from %s to %s", q.Giver.Info.Name, planet.Info.Name),
+				})
 				return gamedata.ModeDocked
 			},
 		})
@@ -144,6 +222,11 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			Text: "Accept quest",
 			OnResolved: func() gamedata.Mode {
 				q.Active = true
+				r.world.PushEvent(gamedata.WorldEvent{
+					Category: gamedata.EventQuest,
+					Planet:   planet,
+					Text:     fmt.Sprintf("Accepted a delivery quest to %s", q.Receiver.Info.Name),
+				})
 				return gamedata.ModeDocked
 			},
 		})
@@ -155,12 +238,63 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		})
 		return strings.Join(lines, "\n")
 
+	case eventHyperjump:
+		mode := player.Mode
+		lines := make([]string, 0, 8)
+		lines = append(lines, cfmt("Plotting a hyperjump from the <p>%s</> system...", planet.System.Name))
+		lines = append(lines, "")
+		for _, s := range r.world.Systems {
+			if s == planet.System {
+				continue
+			}
+			j := player.HyperjumpInfo(planet.System, s)
+			player.RunJumpHooks(r.scene.Rand(), &j)
+			j.FuelCost = gmath.ClampMin(j.FuelCost, 1)
+			numAllied := s.CountPlanets(player.Faction)
+			numNeutral := s.CountPlanets(gamedata.FactionNone)
+			numHostile := len(s.Planets) - numAllied - numNeutral
+			lines = append(lines, cfmt("* <p>%s</>: <g>%d</> allied, <r>%d</> hostile and %d neutral planets, %d hours away",
+				s.Name, numAllied, numHostile, numNeutral, j.Hours))
+			if player.Fuel < j.FuelCost {
+				continue
+			}
+			text := fmt.Sprintf("Hyperjump to %s [%d fuel]", s.Name, j.FuelCost)
+			r.choices = append(r.choices, r.newJumpChoice(text, s.EntryPlanet(player.Faction), j, true))
+		}
+		if len(r.choices) == 0 {
+			lines = append(lines, "")
+			lines = append(lines, "You don't have enough fuel for any hyperjump.")
+		}
+		r.choices = append(r.choices, Choice{
+			Text: "Cancel",
+			OnResolved: func() gamedata.Mode {
+				return mode
+			},
+		})
+		return strings.Join(lines, "\n")
+
+	case eventNavigation:
+		return r.navigationChoices()
+
+	case eventRoutePlanner:
+		return r.routePlannerChoices(event.page)
+
+	case eventWait:
+		return r.waitChoices()
+
+	case eventTransitDistress:
+		return r.transitDistressChoices()
+	case eventTransitMalfunction:
+		return r.transitMalfunctionChoices()
+	case eventTransitAnomaly:
+		return r.transitAnomalyChoices()
+
 	case eventScanArea:
 		lines := make([]string, 0, 6)
 		lines = append(lines, "Scanning area...")
 		foundAnyone := false
-		for i, num := range planet.VesselsByFaction {
-			if num == 0 {
+		for i, vessels := range planet.VesselsByFaction {
+			if len(vessels) == 0 {
 				continue
 			}
 			if !foundAnyone {
@@ -169,9 +303,20 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			foundAnyone = true
 			f := gamedata.Faction(i)
 			if f == player.Faction {
-				lines = append(lines, cfmt("<g>%s</> vessels: <y>%d</>", f.Name(), num))
-			} else {
-				lines = append(lines, cfmt("<r>%s</> vessels: <y>%d</>", f.Name(), num))
+				lines = append(lines, cfmt("<g>%s</> vessels: <y>%d</>", f.Name(), len(vessels)))
+				continue
+			}
+			lines = append(lines, cfmt("<r>%s</> vessels: <y>%d</>", f.Name(), len(vessels)))
+			numUnidentified := 0
+			for _, v := range vessels {
+				if !v.Identified() {
+					numUnidentified++
+					continue
+				}
+				lines = append(lines, "  "+formatVesselInfo(v))
+			}
+			if numUnidentified != 0 && numUnidentified != len(vessels) {
+				lines = append(lines, cfmt("  + <y>%d</> unidentified", numUnidentified))
 			}
 		}
 		if !foundAnyone {
@@ -206,31 +351,38 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		return strings.Join(lines, "\n")
 
 	case eventShipyard:
-		lines := make([]string, 0, 8)
-		lines = append(lines, "A new, improved vessel is available for the veterans.")
-		lines = append(lines, "")
-		lines = append(lines, "In comparison with your current vessel:")
-		lines = append(lines, cfmt("<g>+50</> health"))
-		lines = append(lines, cfmt("<g>+20</> max energy"))
-		lines = append(lines, cfmt("<g>+20</> cargo space"))
-		lines = append(lines, cfmt("<r>-15%</> rotation speed"))
-		lines = append(lines, "")
-		lines = append(lines, cfmt("It costs <y>350</> credits"))
-		if player.Credits >= 350 {
+		rank := gamedata.GetRank(player.Experience)
+		tradeIn := player.Hull.TradeInPrice()
+		lines := make([]string, 0, 16)
+		lines = append(lines, cfmt("Your current hull is <g>%s</>. The shipyard offers <y>%d</> credits for it as a trade-in.", player.Hull.Name, tradeIn))
+		for _, hullName := range planet.HullsAvailable {
+			h := gamedata.FindHullDesign(hullName)
+			if h == player.Hull {
+				continue
+			}
+			price := gmath.ClampMin(r.world.Price(h.Price)-tradeIn, 0)
+			lines = append(lines, "")
+			lines = append(lines, cfmt("<g>%s</> - <y>%d</> credits", h.Name, price))
+			lines = append(lines, formatHullInfo(player.Hull, h))
+			if rank < h.MinRank {
+				lines = append(lines, cfmt("<r>Requires rank %d</>", h.MinRank))
+				continue
+			}
+			if player.Credits < price {
+				continue
+			}
 			r.choices = append(r.choices, Choice{
-				Text: "Buy new vessel",
+				Text: fmt.Sprintf("Buy %s [%d credits]", h.Name, price),
+				Time: 4,
 				OnResolved: func() gamedata.Mode {
-					player.ImprovedHull = true
-					player.MaxCargo += 20
-					player.VesselDesign.Image = assets.ImageVesselPlayerElite
-					player.VesselDesign.MaxEnergy += 20
-					player.VesselDesign.MaxHP += 50
-					player.VesselDesign.RotationSpeed -= 0.8
-					player.Credits -= 350
+					player.Credits -= price
+					player.ChangeHull(h)
 					return gamedata.ModeDocked
 				},
 			})
 		}
+		lines = append(lines, "")
+		lines = append(lines, "Engine upgrades are moved to the new hull, only a half of armor and energy upgrades is kept.")
 		r.choices = append(r.choices, Choice{
 			Text: "Leave shipyard",
 			OnResolved: func() gamedata.Mode {
@@ -331,37 +483,56 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		return strings.Join(lines, "\n")
 
 	case eventWeaponShop:
-		formatWeapon := func(w *gamedata.WeaponDesign) string {
-			if w.Primary {
-				return cfmt("%s (<g>primary</>)", w.Name)
-			}
-			return cfmt("%s (<p>secondary</>)", w.Name)
-		}
 		lines := make([]string, 0, 6)
+		// Keep the slots for the sell and leave options.
+		maxBuyChoices := MaxChoices - 1
+		if len(player.WeaponStorage) != 0 {
+			maxBuyChoices--
+		}
 		if len(planet.WeaponsAvailable) > 0 {
 			lines = append(lines, "The weapon selection include:")
 			for _, weaponName := range planet.WeaponsAvailable {
+				weaponName := weaponName
 				w := gamedata.FindWeaponDesign(weaponName)
-				cost := cfmt(" - <y>%d</> credits", w.Cost)
+				price := r.world.Price(w.Cost)
+				cost := cfmt(" - <y>%d</> credits", price)
 				lines = append(lines, "* "+formatWeapon(w)+cost)
-				if player.Credits >= w.Cost && !player.HasWeapon(w) {
+				if player.Credits >= price && player.CanAcquireWeapon(w) && len(r.choices) < maxBuyChoices {
 					r.choices = append(r.choices, Choice{
 						Text: "Buy " + w.Name,
 						OnResolved: func() gamedata.Mode {
 							planet.WeaponsAvailable = xslices.Remove(planet.WeaponsAvailable, weaponName)
-							player.Credits -= w.Cost
-							if w.Primary {
-								player.VesselDesign.MainWeapon = w
-							} else {
-								player.VesselDesign.SecondaryWeapon = w
-							}
+							player.Credits -= price
+							player.AcquireWeapon(w)
+							r.world.Stats.WeaponsBought++
 							return gamedata.ModeDocked
 						},
 					})
 				}
 			}
 		} else {
-			lines = append(lines, "This weapon shop is empty at the moment. Come again later.")
+			lines = append(lines, "The weapon racks are empty at the moment. Come again later.")
+		}
+		if len(planet.ModulesAvailable) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, cfmt("Vessel modules (<y>%d</> free slots):", player.FreeModuleSlots()))
+			for _, moduleName := range planet.ModulesAvailable {
+				moduleName := moduleName
+				m := gamedata.FindModuleDesign(moduleName)
+				price := r.world.Price(m.Price)
+				lines = append(lines, cfmt("* %s, %s - <y>%d</> credits", m.Name, m.Description, price))
+				if player.Credits >= price && player.FreeModuleSlots() > 0 && len(r.choices) < maxBuyChoices {
+					r.choices = append(r.choices, Choice{
+						Text: "Buy " + m.Name,
+						OnResolved: func() gamedata.Mode {
+							planet.ModulesAvailable = xslices.Remove(planet.ModulesAvailable, moduleName)
+							player.Credits -= price
+							player.InstallModule(m)
+							return gamedata.ModeDocked
+						},
+					})
+				}
+			}
 		}
 		lines = append(lines, "")
 		lines = append(lines, "Your current weapons:")
@@ -371,8 +542,226 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		if player.VesselDesign.SecondaryWeapon != nil {
 			lines = append(lines, "* "+formatWeapon(player.VesselDesign.SecondaryWeapon))
 		}
+		if len(player.WeaponStorage) != 0 {
+			lines = append(lines, "")
+			lines = append(lines, cfmt("Spare weapons in the cargo hold (<y>%d/%d</>):", len(player.WeaponStorage), gamedata.MaxStoredWeapons))
+			for _, w := range player.WeaponStorage {
+				lines = append(lines, "* "+formatWeapon(w))
+			}
+			r.choices = append(r.choices, Choice{
+				Text: "Sell spare weapons",
+				OnResolved: func() gamedata.Mode {
+					r.eventInfo = eventInfo{kind: eventWeaponSell}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		r.choices = append(r.choices, Choice{
+			Text: "Leave equipment shop",
+			OnResolved: func() gamedata.Mode {
+				return gamedata.ModeDocked
+			},
+		})
+		return strings.Join(lines, "\n")
+
+	case eventWeaponSell:
+		lines := make([]string, 0, 6)
+		lines = append(lines, "The shop owner inspects your spare weapons.")
+		lines = append(lines, "")
+		for _, w := range player.WeaponStorage {
+			w := w
+			lines = append(lines, cfmt("* %s - <y>%d</> credits", formatWeapon(w), w.SellPrice()))
+			r.choices = append(r.choices, Choice{
+				Text: "Sell " + w.Name,
+				OnResolved: func() gamedata.Mode {
+					player.RemoveStoredWeapon(w)
+					r.world.AddCredits(gamedata.CreditsEquipmentSale, w.SellPrice())
+					if !xslices.Contains(planet.WeaponsAvailable, w.Name) {
+						planet.WeaponsAvailable = append(planet.WeaponsAvailable, w.Name)
+					}
+					r.eventInfo = eventInfo{kind: eventWeaponShop}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		r.choices = append(r.choices, Choice{
+			Text: "Back to the shop",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventWeaponShop}
+				return gamedata.ModeDocked
+			},
+		})
+		return strings.Join(lines, "\n")
+
+	case eventDistrict:
+		if gamedata.GetRank(player.Experience) >= gamedata.GovernorMinRank {
+			r.choices = append(r.choices, Choice{
+				Time: 1,
+				Text: "Visit governor office",
+				OnResolved: func() gamedata.Mode {
+					r.eventInfo = eventInfo{kind: eventGovernorOffice}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		if !planet.AreasVisited.VisitedBar {
+			r.choices = append(r.choices, Choice{
+				Time: 1,
+				Text: "Visit the local bar",
+				OnResolved: func() gamedata.Mode {
+					planet.AreasVisited.VisitedBar = true
+					r.eventInfo = eventInfo{kind: eventBar}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		if !planet.AreasVisited.VisitedCrewAgency {
+			r.choices = append(r.choices, Choice{
+				Time: 1,
+				Text: "Visit the recruitment office",
+				OnResolved: func() gamedata.Mode {
+					planet.AreasVisited.VisitedCrewAgency = true
+					r.eventInfo = eventInfo{kind: eventCrewAgency}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		r.choices = append(r.choices, Choice{
+			Time: 1,
+			Text: "Visit the bank",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventBank}
+				return gamedata.ModeDocked
+			},
+		})
+		r.choices = append(r.choices, Choice{
+			Time: 1,
+			Text: "Visit the insurance office",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventInsurance}
+				return gamedata.ModeDocked
+			},
+		})
+		r.choices = append(r.choices, Choice{
+			Time: 1,
+			Text: "Visit the black market",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventBlackMarket}
+				return gamedata.ModeDocked
+			},
+		})
+		r.choices = append(r.choices, Choice{
+			Text: "Return to the docks",
+			OnResolved: func() gamedata.Mode {
+				return gamedata.ModeDocked
+			},
+		})
+		if planet.Info.GasGiant {
+			return cfmt("The <p>%s</> station decks are crowded with traders, miners and off-duty pilots.", planet.Info.Name)
+		}
+		return cfmt("The <p>%s</> city streets are busy as usual.", planet.Info.Name)
+
+	case eventBar:
+		return r.barChoices()
+
+	case eventCrewAgency:
+		return r.crewAgencyChoices()
+
+	case eventBank:
+		return r.bankChoices()
+
+	case eventInsurance:
+		return r.insuranceChoices()
+
+	case eventBlackMarket:
+		return r.blackMarketChoices()
+
+	case eventCustoms:
+		return r.customsChoices()
+
+	case eventGovernorOffice:
+		garrisonCreditsCost := 80 + 40*planet.GarrisonLevel
+		garrisonCargoCost := 30 + 15*planet.GarrisonLevel
+		canExpandGarrison := planet.GarrisonLevel < gamedata.MaxPlanetGarrisonLevel
+		productionCost := 100 + 50*planet.ProductionLevel
+		defenseCost := 120 + 40*planet.DefenseLevel
+
+		productionStatus := "idle"
+		if planet.VesselProduction {
+			productionStatus = cfmt("next vessel in <y>%d</> hours", int(math.Ceil(planet.VesselProductionTime)))
+		}
+
+		lines := make([]string, 0, 10)
+		lines = append(lines, cfmt("The <p>%s</> governor is ready to listen to your orders.", planet.Info.Name))
+		lines = append(lines, "")
+		lines = append(lines, cfmt("* Garrison: <y>%d</>/<y>%d</> vessels", planet.NumVessels(planet.Faction), planet.GarrisonLimit))
+		lines = append(lines, cfmt("* Production (level <g>%d</>): %s", planet.ProductionLevel, productionStatus))
+		lines = append(lines, cfmt("* Planetary defenses: level <g>%d</>", planet.DefenseLevel))
+		lines = append(lines, cfmt("* Stance: <g>%s</>", planet.Stance.Name()))
+		lines = append(lines, "")
+		if canExpandGarrison {
+			lines = append(lines, cfmt("Garrison expansion costs <y>%d</> credits or <y>%d</> cargo.", garrisonCreditsCost, garrisonCargoCost))
+		} else {
+			lines = append(lines, "The garrison can't be expanded any further.")
+		}
+
+		if canExpandGarrison && player.Credits >= garrisonCreditsCost {
+			r.choices = append(r.choices, Choice{
+				Text: fmt.Sprintf("Expand garrison [%d credits]", garrisonCreditsCost),
+				Time: 2,
+				OnResolved: func() gamedata.Mode {
+					player.Credits -= garrisonCreditsCost
+					planet.GarrisonLevel++
+					planet.GarrisonLimit += gamedata.GarrisonExpansion
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		if canExpandGarrison && player.Cargo >= garrisonCargoCost {
+			r.choices = append(r.choices, Choice{
+				Text: fmt.Sprintf("Expand garrison [%d cargo]", garrisonCargoCost),
+				Time: 2,
+				OnResolved: func() gamedata.Mode {
+					player.Cargo -= garrisonCargoCost
+					planet.GarrisonLevel++
+					planet.GarrisonLimit += gamedata.GarrisonExpansion
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		if planet.ProductionLevel < gamedata.MaxPlanetProductionLevel && player.Credits >= productionCost {
+			r.choices = append(r.choices, Choice{
+				Text: fmt.Sprintf("Speed up vessel production [%d credits]", productionCost),
+				Time: 4,
+				OnResolved: func() gamedata.Mode {
+					player.Credits -= productionCost
+					planet.ProductionLevel++
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		if planet.DefenseLevel < gamedata.MaxPlanetDefenseLevel && player.Credits >= defenseCost {
+			r.choices = append(r.choices, Choice{
+				Text: fmt.Sprintf("Build planetary defenses [%d credits]", defenseCost),
+				Time: 6,
+				OnResolved: func() gamedata.Mode {
+					player.Credits -= defenseCost
+					planet.DefenseLevel++
+					return gamedata.ModeDocked
+				},
+			})
+		}
+		nextStance := planet.Stance.Next()
 		r.choices = append(r.choices, Choice{
-			Text: "Leave weapon shop",
+			Text: fmt.Sprintf("Switch to %s stance", nextStance.Name()),
+			Time: 1,
+			OnResolved: func() gamedata.Mode {
+				planet.Stance = nextStance
+				return gamedata.ModeDocked
+			},
+		})
+		r.choices = append(r.choices, Choice{
+			Text: "Leave governor office",
 			OnResolved: func() gamedata.Mode {
 				return gamedata.ModeDocked
 			},
@@ -436,22 +825,20 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		return strings.Join(lines, "\n")
 
 	case eventBattle, eventBattleInterrupt:
-		lastDefender := planet.Faction == event.enemy.Faction && planet.VesselsByFaction[event.enemy.Faction] == 1
-		event.enemy.LastDefender = lastDefender
-		pirateAttack := event.enemy.Image == assets.ImageVesselPirate
+		origin := event.enemy.OriginPlanet
+		lastDefender := origin != nil && origin.Faction == event.enemy.Faction && origin.NumVessels(event.enemy.Faction) == 1
+		event.enemy.Design.LastDefender = lastDefender
+		pirateAttack := event.enemy.Design.Image == assets.ImageVesselPirate
 		r.choices = append(r.choices, Choice{
 			Text: "Fight!",
 			Mode: gamedata.ModeCombat,
 			OnResolved: func() gamedata.Mode {
-				if pirateAttack {
+				if pirateAttack && !event.transit && !event.bountyHunter {
 					r.world.PirateSeq++
 				}
-				if event.enemy.Faction != gamedata.FactionNone {
-					planet.VesselsByFaction[event.enemy.Faction]--
-				}
-				if planet.Faction == event.enemy.Faction && planet.VesselsByFaction[event.enemy.Faction] == 0 {
-					planet.Faction = gamedata.FactionNone
-					r.world.PushEvent(fmt.Sprintf("%s lost control over %s", event.enemy.Faction.Name(), planet.Info.Name))
+				if origin != nil {
+					// The vessel is returned to the planet if it survives the battle.
+					origin.RemoveVessel(event.enemy)
 				}
 				r.EventStartBattle.Emit(BattleInfo{
 					Enemy: event.enemy,
@@ -460,11 +847,21 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			},
 		})
 		lines := make([]string, 0, 4)
+		if event.text != "" {
+			lines = append(lines, event.text, "")
+		}
 		if player.Mode == gamedata.ModeAttack {
 			lines = append(lines, "Enemy spotted!")
+			if event.enemy.Identified() {
+				lines = append(lines, formatVesselInfo(event.enemy))
+			}
 		} else if event.kind == eventBattleInterrupt {
 			if pirateAttack {
-				lines = append(lines, cfmt("An <r>unidentified vessel</> opens fire at you."))
+				if event.bountyHunter {
+					lines = append(lines, cfmt("A <r>bounty hunter</> hired by the bank opens fire at you."))
+				} else {
+					lines = append(lines, cfmt("An <r>unidentified vessel</> opens fire at you."))
+				}
 				if player.Fuel >= 5 {
 					r.choices = append(r.choices, Choice{
 						Text: "Retreat [5 fuel]",
@@ -488,6 +885,10 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			lines = append(lines, "* Style 1: WASD for movement, mouse buttons to fire.")
 			lines = append(lines, "* Style 2: WASD for movement, [O] and [P] to fire.")
 			lines = append(lines, "* Style 3: arrows for movement, [Z] and [X] to fire.")
+			lines = append(lines, cfmt("* [Esc] to retreat from the battle (costs <y>%d</> fuel).", gamedata.RetreatFuelCost))
+			if r.world.Player.BattleAbility() != nil {
+				lines = append(lines, "* [Space], [C] or middle mouse button to use an active artifact.")
+			}
 
 		}
 		return strings.Join(lines, "\n")
@@ -507,16 +908,18 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		return cfmt("Scavenged <y>%d</> fuel units.", fuelScavenged)
 
 	case eventMineralsHunt:
-		mineralsFound := r.scene.Rand().IntRange(20, 40)
+		reward := gamedata.Reward{
+			Source: gamedata.RewardMinerals,
+			Cargo:  r.scene.Rand().IntRange(20, 40),
+			Failed: r.scene.Rand().Chance(0.06),
+		}
 		if r.scene.Rand().Chance(0.3) {
-			mineralsFound *= 2
+			reward.Cargo *= 2
 		}
-		if !player.HasArtifact("Lucky Charm") {
-			if r.scene.Rand().Chance(0.06) {
-				mineralsFound = 0
-			}
-		} else {
-			mineralsFound += r.scene.Rand().IntRange(4, 14)
+		player.RunRewardHooks(r.scene.Rand(), &reward)
+		mineralsFound := reward.Cargo
+		if reward.Failed {
+			mineralsFound = 0
 		}
 
 		loaded := mineralsFound
@@ -530,6 +933,12 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			fuelGained = r.scene.Rand().IntRange(4, 8)
 		}
 		damaged := r.scene.Rand().Chance(0.4)
+		var injured *gamedata.CrewMember
+		injuryTime := 0
+		if damaged && player.NumCrew() != 0 && r.scene.Rand().Chance(0.5) {
+			injured = r.pickInjuredCrewMember()
+			injuryTime = r.scene.Rand().IntRange(24, 72)
+		}
 		r.choices = append(r.choices, Choice{
 			Text: "Done",
 			OnResolved: func() gamedata.Mode {
@@ -542,6 +951,9 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 				if damaged {
 					player.VesselHP -= r.scene.Rand().FloatRange(0.1, 0.2)
 				}
+				if injured != nil {
+					injured.InjuryTime += injuryTime
+				}
 				player.Fuel = gmath.ClampMax(player.Fuel+fuelGained, player.MaxFuel)
 				player.LoadCargo(mineralsFound)
 				return gamedata.ModeOrbiting
@@ -565,6 +977,9 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		if damaged {
 			lines = append(lines, "")
 			lines = append(lines, "Your vessel hull was damaged during the act.")
+			if injured != nil {
+				lines = append(lines, cfmt("%s <y>%s</> was injured and needs <y>%d</> hours to recover.", injured.Role.Name(), injured.Name, injuryTime))
+			}
 		}
 		return strings.Join(lines, "\n")
 
@@ -595,7 +1010,7 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 			OnResolved: func() gamedata.Mode {
 				planet.MineralDeposit += player.Cargo
 				player.Cargo = 0
-				player.Credits += totalCost
+				r.world.AddCredits(gamedata.CreditsTrade, totalCost)
 				return gamedata.ModeDocked
 			},
 		})
@@ -608,27 +1023,66 @@ func (r *Runner) generateEventChoices(event eventInfo) string {
 		return cfmt("%s\n\nSell <y>%d</> minerals for <y>%d</> credits?", s, player.Cargo, totalCost)
 
 	case eventBuyFuel:
-		fuelPrice := 0.5
-		maxSpent := 90.0
-		if maxSpent > float64(player.Credits) {
-			maxSpent = float64(player.Credits)
-		}
-		bought := maxSpent / fuelPrice
-		if float64(player.Fuel)+bought > float64(player.MaxFuel) {
-			bought = float64(player.MaxFuel - player.Fuel)
-		}
-		spent := bought * fuelPrice
-		r.choices = append(r.choices, Choice{
-			Text: "Done",
-			OnResolved: func() gamedata.Mode {
-				player.Credits -= int(math.Ceil(spent))
-				player.Fuel += int(math.Ceil(bought))
-				return gamedata.ModeDocked
-			},
-		})
-		return cfmt("Bought <y>%d</> fuel units for <y>%d</> credits.", int(math.Ceil(bought)), int(math.Ceil(spent)))
+		return r.buyFuelChoices()
+
+	case eventRepair:
+		return r.repairChoices()
 
 	default:
 		panic(fmt.Sprintf("unexpected event kind: %d", event.kind))
 	}
 }
+
+func formatVesselInfo(v *gamedata.Vessel) string {
+	design := v.Design
+	parts := make([]string, 0, 4)
+	if design.Elite {
+		parts = append(parts, cfmt("<r>elite</> %s vessel", v.Faction.Name()))
+	} else {
+		parts = append(parts, fmt.Sprintf("%s vessel", v.Faction.Name()))
+	}
+	if design.MainWeapon != nil {
+		parts = append(parts, design.MainWeapon.Name)
+	}
+	if design.SecondaryWeapon != nil {
+		parts = append(parts, design.SecondaryWeapon.Name)
+	}
+	parts = append(parts, cfmt("<y>%d%%</> hull", int(math.Ceil(v.HP*100))))
+	s := "* " + strings.Join(parts, ", ")
+	if v.Veterancy != 0 {
+		s += cfmt(" (veterancy <g>%d</>)", v.Veterancy)
+	}
+	return s
+}
+
+func formatHullInfo(current, h *gamedata.HullDesign) string {
+	parts := make([]string, 0, 8)
+	addDelta := func(name string, v float64, precision int) {
+		if v == 0 {
+			return
+		}
+		switch {
+		case v > 0:
+			parts = append(parts, cfmt("%s <g>+%.*f</>", name, precision, v))
+		default:
+			parts = append(parts, cfmt("%s <r>%.*f</>", name, precision, v))
+		}
+	}
+	addDelta("health", h.MaxHP-current.MaxHP, 0)
+	addDelta("energy", h.MaxEnergy-current.MaxEnergy, 0)
+	addDelta("regen", h.EnergyRegen-current.EnergyRegen, 1)
+	addDelta("speed", h.MaxSpeed-current.MaxSpeed, 0)
+	addDelta("acceleration", h.Acceleration-current.Acceleration, 0)
+	addDelta("rotation", float64(h.RotationSpeed-current.RotationSpeed), 1)
+	addDelta("cargo", float64(h.MaxCargo-current.MaxCargo), 0)
+	addDelta("fuel", float64(h.MaxFuel-current.MaxFuel), 0)
+	addDelta("slots", float64(h.Slots-current.Slots), 0)
+	return "  " + strings.Join(parts, ", ")
+}
+
+func formatWeapon(w *gamedata.WeaponDesign) string {
+	if w.Primary {
+		return cfmt("%s (<g>primary</>)", w.Name)
+	}
+	return cfmt("%s (<p>secondary</>)", w.Name)
+}
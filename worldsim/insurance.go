@@ -0,0 +1,79 @@
+package worldsim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) insuranceChoices() string {
+	player := r.world.Player
+
+	lines := make([]string, 0, 8)
+	lines = append(lines, "The insurance office covers the vessel destruction.")
+	lines = append(lines, "")
+	lines = append(lines, cfmt("If your vessel is destroyed, you will be rescued to the nearest allied planet and get a basic <g>%s</> hull.", gamedata.InsuranceHull))
+	lines = append(lines, "The cargo, artifacts, spare weapons, modules and workshop upgrades are lost. The main weapon is kept.")
+	lines = append(lines, "")
+
+	if player.Insured {
+		lines = append(lines, cfmt("Your vessel is <g>insured</>."))
+	} else {
+		price := r.world.Price(player.InsurancePrice())
+		lines = append(lines, cfmt("The policy for your <g>%s</> costs <y>%d</> credits. It covers a single loss.", player.Hull.Name, price))
+		if player.Credits >= price {
+			r.choices = append(r.choices, Choice{
+				Time: 1,
+				Text: fmt.Sprintf("Buy the insurance policy [%d credits]", price),
+				OnResolved: func() gamedata.Mode {
+					player.Credits -= price
+					player.Insured = true
+					r.eventInfo = eventInfo{kind: eventInsurance}
+					return gamedata.ModeDocked
+				},
+			})
+		}
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Leave the insurance office",
+		OnResolved: func() gamedata.Mode {
+			r.eventInfo = eventInfo{kind: eventDistrict}
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+// insuranceRescueChoices is used instead of the game over if the vessel was insured.
+// It returns an empty string if there is nowhere to rescue the player.
+func (r *Runner) insuranceRescueChoices() string {
+	player := r.world.Player
+	dst := r.world.NearestAlliedPlanet(player.Planet)
+	if dst == nil {
+		return ""
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Accept the insurance rescue",
+		OnResolved: func() gamedata.Mode {
+			r.world.RescueVessel(dst)
+			dst.AreasVisited = gamedata.PlanetVisitStatus{}
+			r.world.PushEvent(gamedata.WorldEvent{
+				Category: gamedata.EventBattle,
+				Planet:   dst,
+				Text:     fmt.Sprintf("The insured vessel was destroyed, the captain was rescued to %s", dst.Info.Name),
+			})
+			return gamedata.ModeDocked
+		},
+	})
+
+	lines := []string{
+		"Your vessel was destroyed in battle.",
+		"",
+		cfmt("Luckily, it was insured. The rescue team will bring you to <p>%s</> and provide a new <g>%s</> hull.", dst.Info.Name, gamedata.InsuranceHull),
+	}
+	return strings.Join(lines, "\n")
+}
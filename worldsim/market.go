@@ -0,0 +1,134 @@
+package worldsim
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) formatMarketPrices(prices gamedata.MarketPrices) []string {
+	lines := make([]string, 0, 2)
+	if prices.WarPressure > 0 {
+		lines = append(lines, cfmt("The hostile vessels nearby raise the prices by <r>%d%%</>.", int(math.Round(prices.WarPressure*100))))
+	}
+	switch rep := r.world.Player.Reputation; {
+	case rep > 0:
+		lines = append(lines, cfmt("Your reputation gives you a <g>%d%%</> discount.", int(math.Round(20*float64(rep)/gamedata.MaxReputation))))
+	case rep < 0:
+		lines = append(lines, cfmt("Your reputation makes the prices <r>%d%%</> higher.", int(math.Round(-20*float64(rep)/gamedata.MaxReputation))))
+	}
+	return lines
+}
+
+// marketAmounts returns the purchase options: a few fixed amounts and the maximum one.
+func marketAmounts(maxAmount int, steps ...int) []int {
+	amounts := make([]int, 0, len(steps)+1)
+	for _, n := range steps {
+		if n < maxAmount {
+			amounts = append(amounts, n)
+		}
+	}
+	if maxAmount > 0 {
+		amounts = append(amounts, maxAmount)
+	}
+	return amounts
+}
+
+func (r *Runner) buyFuelChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	prices := r.world.MarketPrices(planet)
+	price := prices.Fuel
+
+	lines := make([]string, 0, 6)
+	lines = append(lines, cfmt("The <p>%s</> fuel price is <y>%.2f</> credits per unit.", planet.Info.Name, price))
+	lines = append(lines, r.formatMarketPrices(prices)...)
+	lines = append(lines, "")
+	lines = append(lines, cfmt("Fuel: <y>%d</>/%d", player.Fuel, player.MaxFuel))
+
+	affordable := int(float64(player.Credits) / price)
+	maxAmount := gmath.ClampMax(player.MaxFuel-player.Fuel, affordable)
+	for _, amount := range marketAmounts(maxAmount, 10, 25, 50) {
+		amount := amount
+		cost := int(math.Ceil(float64(amount) * price))
+		text := fmt.Sprintf("Buy %d fuel [%d credits]", amount, cost)
+		if amount == player.MaxFuel-player.Fuel {
+			text = fmt.Sprintf("Fill the tank [%d credits]", cost)
+		}
+		r.choices = append(r.choices, Choice{
+			Text: text,
+			OnResolved: func() gamedata.Mode {
+				player.Credits -= cost
+				player.Fuel += amount
+				r.eventInfo = eventInfo{kind: eventBuyFuel}
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Leave the fuel depot",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) repairChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	prices := r.world.MarketPrices(planet)
+	price := prices.Repair
+
+	lines := make([]string, 0, 6)
+	lines = append(lines, cfmt("The <p>%s</> repair dock charges <y>%.2f</> credits per structure percent.", planet.Info.Name, price))
+	lines = append(lines, r.formatMarketPrices(prices)...)
+	lines = append(lines, "")
+	lines = append(lines, cfmt("Vessel structure: <y>%d%%</>", int(player.VesselHP*100)))
+
+	// Every 5% is 1 hour.
+	// Repair of 100% is 20 hours.
+	missing := int(math.Ceil((1.0 - player.VesselHP) * 100))
+	affordable := int(float64(player.Credits) / price)
+	maxAmount := gmath.ClampMax(missing, affordable)
+	for _, amount := range marketAmounts(maxAmount, 10, 25, 50) {
+		amount := amount
+		cost := int(math.Ceil(float64(amount) * price))
+		repairTime := gmath.ClampMin(int(math.Ceil(float64(amount)/5*player.RepairTimeMultiplier())), 1)
+		text := fmt.Sprintf("Repair %d%% [%d credits]", amount, cost)
+		if amount == missing {
+			text = fmt.Sprintf("Repair fully [%d credits]", cost)
+		}
+		r.choices = append(r.choices, Choice{
+			Time: repairTime,
+			Text: text,
+			OnResolved: func() gamedata.Mode {
+				player.Credits -= cost
+				player.VesselHP = gmath.ClampMax(player.VesselHP+float64(amount)/100, 1.0)
+				if amount == missing {
+					player.TrainCrew(gamedata.CrewEngineer, 2)
+				} else {
+					player.TrainCrew(gamedata.CrewEngineer, 1)
+				}
+				if player.VesselHP < 1.0 {
+					r.eventInfo = eventInfo{kind: eventRepair}
+				}
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Leave the repair dock",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
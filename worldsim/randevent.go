@@ -0,0 +1,284 @@
+package worldsim
+
+import (
+	"strings"
+
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) processRandomEvents() bool {
+	if r.world.RandomEventDelay != 0 {
+		return false
+	}
+
+	player := r.world.Player
+	switch player.Mode {
+	case gamedata.ModeJump, gamedata.ModeInTransit, gamedata.ModeAttack, gamedata.ModeCombat:
+		// Random events never interrupt these actions.
+		return false
+	}
+
+	if !r.scene.Rand().Chance(0.15) {
+		return false
+	}
+
+	picker := gmath.NewRandPicker[*gamedata.RandomEventDesign](r.scene.Rand())
+	for _, e := range gamedata.RandomEvents {
+		if e.Unique && xslices.Contains(r.world.SeenRandomEvents, e.ID) {
+			continue
+		}
+		if !r.eventConditionsMet(&e.Conditions) {
+			continue
+		}
+		picker.AddOption(e, e.Weight)
+	}
+	if picker.IsEmpty() {
+		return false
+	}
+
+	e := picker.Pick()
+	if e.Unique {
+		r.world.SeenRandomEvents = append(r.world.SeenRandomEvents, e.ID)
+	}
+	r.world.RandomEventDelay = r.scene.Rand().FloatRange(40, 90)
+	r.eventInfo = eventInfo{
+		kind:        eventRandom,
+		randomEvent: e,
+	}
+	return true
+}
+
+func planetRelation(player *gamedata.Player, planet *gamedata.Planet) string {
+	switch planet.Faction {
+	case player.Faction:
+		return "allied"
+	case gamedata.FactionNone:
+		return "neutral"
+	default:
+		return "hostile"
+	}
+}
+
+func (r *Runner) eventConditionsMet(c *gamedata.EventConditions) bool {
+	player := r.world.Player
+	planet := player.Planet
+
+	if len(c.Modes) != 0 && !xslices.Contains(c.Modes, player.Mode) {
+		return false
+	}
+	if len(c.PlanetFactions) != 0 && !xslices.Contains(c.PlanetFactions, planetRelation(player, planet)) {
+		return false
+	}
+	if c.GasGiant != nil && *c.GasGiant != planet.Info.GasGiant {
+		return false
+	}
+
+	if player.Cargo < c.MinCargo {
+		return false
+	}
+	if c.MaxCargo != 0 && player.Cargo > c.MaxCargo {
+		return false
+	}
+	if player.FreeCargoSpace() < c.MinFreeCargo {
+		return false
+	}
+	if player.Credits < c.MinCredits || player.Fuel < c.MinFuel {
+		return false
+	}
+
+	for _, a := range c.Artifacts {
+		if !player.HasArtifact(a) {
+			return false
+		}
+	}
+	for _, a := range c.NoArtifacts {
+		if player.HasArtifact(a) {
+			return false
+		}
+	}
+
+	day := (r.world.GameTime / 24) + 1
+	if day < c.MinDay {
+		return false
+	}
+	if c.MaxDay != 0 && day > c.MaxDay {
+		return false
+	}
+
+	if gamedata.GetRank(player.Experience) < c.MinRank {
+		return false
+	}
+
+	return true
+}
+
+func (r *Runner) formatEventText(s string) string {
+	replacer := strings.NewReplacer(
+		"{planet}", r.world.Player.Planet.Info.Name,
+		"{faction}", r.world.Player.Faction.Name(),
+	)
+	return cfmt(replacer.Replace(s))
+}
+
+func (r *Runner) randomEventChoices(e *gamedata.RandomEventDesign) string {
+	restMode := gamedata.ModeOrbiting
+	if r.world.Player.Mode == gamedata.ModeDocked {
+		restMode = gamedata.ModeDocked
+	}
+
+	for _, c := range e.Choices {
+		if len(r.choices) >= MaxChoices {
+			break
+		}
+		if !r.eventConditionsMet(&c.Conditions) {
+			continue
+		}
+		c := c
+		r.choices = append(r.choices, Choice{
+			Text: c.Text,
+			Time: c.Time,
+			OnResolved: func() gamedata.Mode {
+				r.applyRandomEventChoice(c)
+				return restMode
+			},
+		})
+	}
+	if len(r.choices) == 0 {
+		r.choices = append(r.choices, Choice{
+			Text: "Continue",
+			OnResolved: func() gamedata.Mode {
+				return restMode
+			},
+		})
+	}
+
+	return r.formatEventText(e.Text)
+}
+
+func (r *Runner) applyRandomEventChoice(c *gamedata.RandomEventChoice) {
+	lines := make([]string, 0, 6)
+	if c.Result != "" {
+		lines = append(lines, r.formatEventText(c.Result))
+	}
+	effectLines := r.applyEventEffects(&c.Effects)
+	if len(effectLines) != 0 {
+		if len(lines) != 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, effectLines...)
+	}
+	text := strings.Join(lines, "\n")
+
+	if c.Effects.Battle != "" {
+		r.eventInfo = eventInfo{
+			kind:  eventBattleInterrupt,
+			enemy: r.randomEventEnemy(c.Effects.Battle),
+			text:  text,
+		}
+		return
+	}
+
+	if text != "" {
+		r.eventInfo = eventInfo{
+			kind: eventMessage,
+			text: text,
+		}
+	}
+}
+
+func (r *Runner) randomEventEnemy(kind string) *gamedata.Vessel {
+	if kind == "pirate" {
+		return r.makePirate()
+	}
+
+	// Prefer the vessels that are already present around the planet.
+	planet := r.world.Player.Planet
+	r.encounterOptions = r.encounterOptions[:0]
+	for i, vessels := range planet.VesselsByFaction {
+		f := gamedata.Faction(i)
+		if len(vessels) == 0 || f == r.world.Player.Faction || f == gamedata.FactionNone {
+			continue
+		}
+		r.encounterOptions = append(r.encounterOptions, f)
+	}
+	var enemy *gamedata.Vessel
+	if len(r.encounterOptions) != 0 {
+		f := gmath.RandElem(r.scene.Rand(), r.encounterOptions)
+		enemy = gmath.RandElem(r.scene.Rand(), planet.VesselsByFaction[f])
+		enemy.OriginPlanet = planet
+	} else {
+		f := gamedata.FactionB
+		if r.scene.Rand().Bool() {
+			f = gamedata.FactionC
+		}
+		if f == r.world.Player.Faction {
+			f = gamedata.FactionA
+		}
+		enemy = gamedata.NewVessel(f)
+	}
+	gamedata.InitVesselDesign(r.scene.Rand(), r.world, enemy)
+	return enemy
+}
+
+func (r *Runner) randIntRange(v gamedata.IntRange) int {
+	if v.Min == v.Max {
+		return v.Min
+	}
+	return r.scene.Rand().IntRange(v.Min, v.Max)
+}
+
+func formatEffectDelta(name string, v int) string {
+	if v >= 0 {
+		return cfmt("%s: <g>+%d</>", name, v)
+	}
+	return cfmt("%s: <r>%d</>", name, v)
+}
+
+func (r *Runner) applyEventEffects(e *gamedata.EventEffects) []string {
+	player := r.world.Player
+	var lines []string
+
+	if !e.Credits.IsZero() {
+		v := gmath.ClampMin(r.randIntRange(e.Credits), -player.Credits)
+		r.world.AddCredits(gamedata.CreditsEvent, v)
+		lines = append(lines, formatEffectDelta("Credits", v))
+	}
+	if !e.Fuel.IsZero() {
+		v := gmath.Clamp(r.randIntRange(e.Fuel), -player.Fuel, player.MaxFuel-player.Fuel)
+		player.Fuel += v
+		lines = append(lines, formatEffectDelta("Fuel", v))
+	}
+	if !e.Cargo.IsZero() {
+		v := r.randIntRange(e.Cargo)
+		if v > 0 {
+			v = player.LoadCargo(v)
+		} else {
+			v = gmath.ClampMin(v, -player.Cargo)
+			player.Cargo += v
+		}
+		lines = append(lines, formatEffectDelta("Cargo", v))
+	}
+	if !e.Reputation.IsZero() {
+		v := r.randIntRange(e.Reputation)
+		player.Reputation = gmath.Clamp(player.Reputation+v, gamedata.MinReputation, gamedata.MaxReputation)
+		lines = append(lines, formatEffectDelta("Reputation", v))
+	}
+	if !e.Experience.IsZero() {
+		v := gmath.ClampMin(r.randIntRange(e.Experience), 0)
+		player.Experience += v
+		lines = append(lines, formatEffectDelta("Experience", v))
+	}
+	if !e.HP.IsZero() {
+		v := e.HP.Min
+		if e.HP.Min != e.HP.Max {
+			v = r.scene.Rand().FloatRange(e.HP.Min, e.HP.Max)
+		}
+		// Random events can damage the vessel, but they can't destroy it.
+		player.VesselHP = gmath.Clamp(player.VesselHP+v, 0.05, 1.0)
+		lines = append(lines, formatEffectDelta("Vessel structure (%)", int(v*100)))
+	}
+
+	return lines
+}
@@ -0,0 +1,159 @@
+package worldsim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) navigationChoices() string {
+	player := r.world.Player
+	mode := player.Mode
+
+	lines := make([]string, 0, 4)
+	lines = append(lines, cfmt("Navigation console of the <p>%s</> system.", player.Planet.System.Name))
+	if player.Destination != nil {
+		lines = append(lines, cfmt("Current destination: <p>%s</>.", player.Destination.Info.Name))
+	}
+
+	canJump := mode != gamedata.ModeDocked
+	if canJump {
+		r.choices = append(r.choices, Choice{
+			Text: "Open the route planner",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventRoutePlanner}
+				return mode
+			},
+		})
+	}
+	if canJump && len(r.world.Systems) > 1 {
+		r.choices = append(r.choices, Choice{
+			Text: "Plot a hyperjump",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventHyperjump}
+				return mode
+			},
+		})
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Wait / hold position",
+		OnResolved: func() gamedata.Mode {
+			r.eventInfo = eventInfo{kind: eventWait}
+			return mode
+		},
+	})
+	r.choices = append(r.choices, Choice{
+		Text: "Close the navigation console",
+		OnResolved: func() gamedata.Mode {
+			return mode
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) routePlannerChoices(page int) string {
+	player := r.world.Player
+	mode := player.Mode
+
+	destinations := make([]*gamedata.Planet, 0, len(r.world.Planets))
+	for _, p := range r.world.Planets {
+		if p != player.Planet {
+			destinations = append(destinations, p)
+		}
+	}
+
+	// The destination choices take whatever is left after the control choices.
+	pageSize := MaxChoices - 2
+	if player.Destination != nil {
+		pageSize--
+	}
+	if len(destinations) > pageSize {
+		pageSize--
+	}
+	numPages := (len(destinations) + pageSize - 1) / pageSize
+	if page >= numPages {
+		page = 0
+	}
+
+	lines := make([]string, 0, 12)
+	lines = append(lines, cfmt("Route planner, optimizing for <y>%s</>.", player.RouteGoal.Name()))
+	if player.Destination != nil {
+		lines = append(lines, cfmt("Current destination: <p>%s</>.", player.Destination.Info.Name))
+	}
+	lines = append(lines, "")
+
+	from := page * pageSize
+	to := from + pageSize
+	if to > len(destinations) {
+		to = len(destinations)
+	}
+	for _, p := range destinations[from:to] {
+		p := p
+		name := p.Info.Name
+		if p.System != player.Planet.System {
+			name = fmt.Sprintf("%s (%s)", p.Info.Name, p.System.Name)
+		}
+		route := r.world.PlanRoute(r.scene.Rand(), p, player.RouteGoal)
+		if route == nil {
+			lines = append(lines, cfmt("* <p>%s</>: no route", name))
+			continue
+		}
+		hops := "hops"
+		if len(route.Hops) == 1 {
+			hops = "hop"
+		}
+		lines = append(lines, cfmt("* <p>%s</>: %d %s, <y>%d</> fuel, %d hours", name, len(route.Hops), hops, route.FuelCost, route.Hours))
+		r.choices = append(r.choices, Choice{
+			Text: "Set course to " + p.Info.Name,
+			OnResolved: func() gamedata.Mode {
+				player.Destination = p
+				return mode
+			},
+		})
+	}
+	if numPages > 1 {
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("Page %d/%d", page+1, numPages))
+	}
+
+	nextGoal := gamedata.RouteMinTime
+	if player.RouteGoal == gamedata.RouteMinTime {
+		nextGoal = gamedata.RouteMinFuel
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Optimize for " + nextGoal.Name(),
+		OnResolved: func() gamedata.Mode {
+			player.RouteGoal = nextGoal
+			r.eventInfo = eventInfo{kind: eventRoutePlanner, page: page}
+			return mode
+		},
+	})
+	if numPages > 1 {
+		r.choices = append(r.choices, Choice{
+			Text: "More destinations",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventRoutePlanner, page: page + 1}
+				return mode
+			},
+		})
+	}
+	if player.Destination != nil {
+		r.choices = append(r.choices, Choice{
+			Text: "Clear the destination",
+			OnResolved: func() gamedata.Mode {
+				player.Destination = nil
+				return mode
+			},
+		})
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Close the route planner",
+		OnResolved: func() gamedata.Mode {
+			return mode
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
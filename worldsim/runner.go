@@ -2,7 +2,6 @@ package worldsim
 
 import (
 	"fmt"
-	"math"
 	"strings"
 
 	"github.com/quasilyte/ge"
@@ -25,24 +24,43 @@ type Runner struct {
 
 	eventInfo eventInfo
 
+	// wait is set by the wait choice, it's consumed by the next AdvanceTime call.
+	wait *waitInfo
+
+	// canLeave is set when the current choices are the regular ones.
+	// It's not safe to leave the choice screen in the middle of an event.
+	canLeave bool
+
 	EventStartBattle gsignal.Event[BattleInfo]
 	EventGameOver    gsignal.Event[bool]
 }
 
 type BattleInfo struct {
-	Enemy *gamedata.VesselDesign
+	Enemy *gamedata.Vessel
 }
 
 type eventInfo struct {
 	kind eventKind
 
-	enemy *gamedata.VesselDesign
+	enemy *gamedata.Vessel
+
+	randomEvent *gamedata.RandomEventDesign
+
+	text string
+
+	// page is used by the paginated event screens.
+	page int
+
+	// transit is set for the events that happen during a jump.
+	transit bool
+
+	// bountyHunter is set for the battles caused by the overdue debt.
+	bountyHunter bool
 }
 
 type jumpOption struct {
-	planet   *gamedata.Planet
-	fuelCost int
-	time     int
+	planet *gamedata.Planet
+	jump   gamedata.JumpInfo
 }
 
 type GeneratedChoices struct {
@@ -72,6 +90,8 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 	player := r.world.Player
 	planet := r.world.Player.Planet
 
+	r.canLeave = false
+
 	if player.Mode == gamedata.ModeAfterCombat {
 		player.Mode = gamedata.ModeOrbiting
 		s := r.afterBattleChoices()
@@ -91,6 +111,22 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		}
 	}
 
+	if r.world.TextQuest != nil {
+		s := r.textQuestChoices()
+		return GeneratedChoices{
+			Choices: r.choices,
+			Text:    s,
+		}
+	}
+
+	if player.Transit != nil {
+		s := r.transitChoices()
+		return GeneratedChoices{
+			Choices: r.choices,
+			Text:    s,
+		}
+	}
+
 	r.textLines = append(r.textLines, genModeText(r.scene, r.world))
 
 	canJump := true
@@ -101,6 +137,17 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		isIdleMode = true
 	}
 
+	if player.CustomsPending && player.Mode == gamedata.ModeJustEntered && r.world.CustomsScanChance(planet) > 0 {
+		r.choices = append(r.choices, Choice{
+			Time: 2,
+			Text: "Sneak past the customs",
+			Mode: gamedata.ModeSneaking,
+			OnResolved: func() gamedata.Mode {
+				return gamedata.ModeOrbiting
+			},
+		})
+	}
+
 	if len(r.choices) < MaxChoices && planet.Faction == player.Faction {
 		switch player.Mode {
 		case gamedata.ModeJustEntered, gamedata.ModeOrbiting:
@@ -116,6 +163,7 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 				Mode: gamedata.ModeOrbiting,
 				OnResolved: func() gamedata.Mode {
 					player.Planet.AreasVisited = gamedata.PlanetVisitStatus{}
+					player.RunDockingHooks(r.scene.Rand())
 					return gamedata.ModeDocked
 				},
 			})
@@ -123,8 +171,7 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 	}
 
 	if len(r.choices) < MaxChoices && isIdleMode {
-		switch planet.Faction {
-		case gamedata.FactionB, gamedata.FactionC:
+		if planet.Faction != gamedata.FactionNone && planet.Faction != player.Faction {
 			s := "Attack enemy garrison"
 			h := 1
 			r.choices = append(r.choices, Choice{
@@ -184,29 +231,36 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 	}
 
 	if len(r.choices) < MaxChoices && player.Mode == gamedata.ModeDocked {
-		if player.VesselHP < 1.0 {
-			price := r.scene.Rand().FloatRange(0.3, 0.5)
-			repairAmount := 1.0 - player.VesselHP
-			fullPrice := int(math.Ceil((100 * repairAmount) * price))
-			if player.Credits > fullPrice {
-				// Every 5% is 1 hour.
-				// Repair of 100% is 20 hours.
-				repairTime := int(math.Ceil(player.VesselHP * 20))
-				r.choices = append(r.choices, Choice{
-					Time: repairTime,
-					Text: "Repair vessel",
-					OnResolved: func() gamedata.Mode {
-						player.Credits -= fullPrice
-						player.VesselHP = 1.0
-						return gamedata.ModeDocked
-					},
-				})
-			}
+		s := "Visit the city"
+		if planet.Info.GasGiant {
+			s = "Walk the station decks"
+		}
+		r.choices = append(r.choices, Choice{
+			Time: 1,
+			Text: s,
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventDistrict}
+				return gamedata.ModeDocked
+			},
+		})
+	}
+
+	if len(r.choices) < MaxChoices && player.Mode == gamedata.ModeDocked {
+		prices := r.world.MarketPrices(planet)
+		if player.VesselHP < 1.0 && float64(player.Credits) >= prices.Repair {
+			r.choices = append(r.choices, Choice{
+				Time: 1,
+				Text: "Visit the repair dock",
+				OnResolved: func() gamedata.Mode {
+					r.eventInfo = eventInfo{kind: eventRepair}
+					return gamedata.ModeDocked
+				},
+			})
 		}
 	}
 
 	if len(r.choices) < MaxChoices && player.Mode == gamedata.ModeDocked {
-		if player.Credits >= 5 && player.Fuel < player.MaxFuel {
+		if float64(player.Credits) >= r.world.MarketPrices(planet).Fuel && player.Fuel < player.MaxFuel {
 			r.choices = append(r.choices, Choice{
 				Time: 2,
 				Text: "Buy fuel",
@@ -225,9 +279,8 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 				numPlayerBases++
 			}
 		}
-		canUpgradeHull := !player.ImprovedHull && player.Battles > 4 &&
-			player.Experience >= 20 && numPlayerBases >= 2
-		if canUpgradeHull && r.scene.Rand().Chance(0.3) {
+		hasShipyard := len(planet.HullsAvailable) != 0 && player.Battles > 4 && numPlayerBases >= 2
+		if hasShipyard && r.scene.Rand().Chance(0.3) {
 			r.choices = append(r.choices, Choice{
 				Time: 2,
 				Text: "Visit shipyard",
@@ -239,7 +292,7 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		} else if planet.ShopModeWeapons {
 			r.choices = append(r.choices, Choice{
 				Time: 1,
-				Text: "Visit weapons shop",
+				Text: "Visit equipment shop",
 				OnResolved: func() gamedata.Mode {
 					r.eventInfo = eventInfo{kind: eventWeaponShop}
 					return gamedata.ModeDocked
@@ -257,7 +310,8 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		}
 	}
 
-	if len(r.choices) < MaxChoices && player.Mode == gamedata.ModeDocked {
+	// Keep one slot for the take off option.
+	if len(r.choices) < MaxChoices-1 && player.Mode == gamedata.ModeDocked {
 		if r.world.NextUpgradeDelay == 0 {
 			r.choices = append(r.choices, Choice{
 				Time: 1,
@@ -270,7 +324,7 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		}
 	}
 
-	if len(r.choices) < MaxChoices && player.Mode == gamedata.ModeDocked && !planet.AreasVisited.VisitedMineralsMarket {
+	if len(r.choices) < MaxChoices-1 && player.Mode == gamedata.ModeDocked && !planet.AreasVisited.VisitedMineralsMarket {
 		if player.Cargo > 0 && r.scene.Rand().Chance(0.9) {
 			r.choices = append(r.choices, Choice{
 				Time: 2,
@@ -320,7 +374,29 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 	// 	})
 	// }
 
-	if len(r.choices) < MaxChoices && player.Cargo < player.MaxCargo && player.VesselHP > 0.3 {
+	if player.Destination == planet {
+		player.Destination = nil
+		r.textLines = append(r.textLines, "", "You have reached the route destination.")
+	}
+
+	// The route hop and the navigation go before the optional actions,
+	// so they can't be pushed out of the list.
+	// The navigation console is also used to wait while docked.
+	var routeHop *gamedata.Planet
+	if canJump && player.Destination != nil {
+		routeHop = r.addRouteChoice()
+	}
+	if (isIdleMode || player.Mode == gamedata.ModeDocked) && len(r.choices) < MaxChoices {
+		r.choices = append(r.choices, Choice{
+			Text: "Open the navigation console",
+			OnResolved: func() gamedata.Mode {
+				r.eventInfo = eventInfo{kind: eventNavigation}
+				return player.Mode
+			},
+		})
+	}
+
+	if len(r.choices) < MaxChoices && player.FreeCargoSpace() > 0 && player.VesselHP > 0.3 {
 		switch r.world.Player.Mode {
 		case gamedata.ModeJustEntered, gamedata.ModeOrbiting:
 			if planet.MineralsDelay == 0 && r.scene.Rand().Chance(0.7) {
@@ -357,8 +433,8 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 
 	if len(r.choices) < MaxChoices && isIdleMode && planet.Faction == player.Faction {
 		numFactions := 0
-		for _, num := range player.Planet.VesselsByFaction {
-			if num == 0 {
+		for _, vessels := range player.Planet.VesselsByFaction {
+			if len(vessels) == 0 {
 				continue
 			}
 			numFactions++
@@ -376,15 +452,14 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 	}
 
 	if len(r.choices) < MaxChoices && isIdleMode {
-		h := 3
-		if player.HasArtifact("Scantide") {
-			h = 1
-		}
+		h := player.ScanTime()
+		player.RunScanHooks(r.scene.Rand(), &h)
 		r.choices = append(r.choices, Choice{
 			Time: h,
 			Text: "Scout the area",
 			Mode: gamedata.ModeSneaking,
 			OnResolved: func() gamedata.Mode {
+				player.TrainCrew(gamedata.CrewScientist, 1)
 				r.eventInfo = eventInfo{kind: eventScanArea}
 				return gamedata.ModeOrbiting
 			},
@@ -393,26 +468,21 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 
 	if canJump {
 		// Find all possible routes first.
-		for _, p := range r.world.Planets {
-			if p == player.Planet {
+		for _, p := range player.Planet.System.Planets {
+			if p == player.Planet || p == routeHop {
 				continue
 			}
-			dist := player.Planet.Info.MapOffset.DistanceTo(p.Info.MapOffset)
-			fuelNeeded := gmath.ClampMin(int(dist*player.FuelUsage), 1)
-			if player.HasArtifact("Jumper") {
-				fuelNeeded = gmath.ClampMin(fuelNeeded-10, 1)
-			}
-			if player.Fuel < fuelNeeded {
+			j := player.PlanetJumpInfo(player.Planet, p)
+			if j.Dist > player.MaxJumpDist {
 				continue
 			}
-			if dist > player.MaxJumpDist {
+			player.RunJumpHooks(r.scene.Rand(), &j)
+			if player.Fuel < j.FuelCost {
 				continue
 			}
-			hours := int(math.Ceil(dist / player.JumpSpeed))
 			r.jumpOptions = append(r.jumpOptions, jumpOption{
-				planet:   p,
-				fuelCost: fuelNeeded,
-				time:     hours,
+				planet: p,
+				jump:   j,
 			})
 		}
 		gmath.Shuffle(r.scene.Rand(), r.jumpOptions)
@@ -420,21 +490,93 @@ func (r *Runner) GenerateChoices() GeneratedChoices {
 		for len(r.jumpOptions) > 0 && len(r.choices) < MaxChoices {
 			j := r.jumpOptions[len(r.jumpOptions)-1]
 			r.jumpOptions = r.jumpOptions[:len(r.jumpOptions)-1]
-			r.choices = append(r.choices, Choice{
-				Time: j.time,
-				Text: fmt.Sprintf("Jump to %s [%d fuel]", j.planet.Info.Name, j.fuelCost),
-				Mode: gamedata.ModeJump,
-				OnResolved: func() gamedata.Mode {
-					player.Planet = j.planet
-					player.Fuel -= j.fuelCost
-					return gamedata.ModeJustEntered
-				},
-			})
+			text := fmt.Sprintf("Jump to %s [%d fuel]", j.planet.Info.Name, j.jump.FuelCost)
+			r.choices = append(r.choices, r.newJumpChoice(text, j.planet, j.jump, false))
 		}
 	}
 
+	r.canLeave = true
+
 	return GeneratedChoices{
 		Text:    strings.Join(r.textLines, "\n"),
 		Choices: r.choices,
 	}
 }
+
+// addRouteChoice adds the next route hop choice if the route can be followed.
+// It returns the next hop planet, so it's not listed among the regular jumps.
+func (r *Runner) addRouteChoice() *gamedata.Planet {
+	player := r.world.Player
+	dst := player.Destination
+
+	route := r.world.PlanRoute(r.scene.Rand(), dst, player.RouteGoal)
+	if route == nil {
+		player.Destination = nil
+		r.textLines = append(r.textLines, "", cfmt("There is no route to <p>%s</> anymore.", dst.Info.Name))
+		return nil
+	}
+
+	hop := route.Hops[0]
+	r.textLines = append(r.textLines, "", cfmt("Route to <p>%s</>: %d hops left, <y>%d</> fuel and %d hours in total.",
+		dst.Info.Name, len(route.Hops), route.FuelCost, route.Hours))
+	if player.Fuel < hop.Jump.FuelCost {
+		r.textLines = append(r.textLines, cfmt("Not enough fuel for the next hop to <p>%s</>.", hop.Dst.Info.Name))
+		return nil
+	}
+	if len(r.choices) >= MaxChoices {
+		return nil
+	}
+
+	jumpKind := "jump"
+	if hop.Hyperjump {
+		jumpKind = "hyperjump"
+	}
+	text := fmt.Sprintf("Follow the route: %s to %s [%d fuel]", jumpKind, hop.Dst.Info.Name, hop.Jump.FuelCost)
+	r.choices = append(r.choices, r.newJumpChoice(text, hop.Dst, hop.Jump, hop.Hyperjump))
+	return hop.Dst
+}
+
+// newJumpChoice creates a jump choice; the fuel is spent when the jump starts.
+// The jump can be interrupted by an in-transit event, see continueJumpChoice.
+func (r *Runner) newJumpChoice(text string, dst *gamedata.Planet, j gamedata.JumpInfo, hyperjump bool) Choice {
+	player := r.world.Player
+	return Choice{
+		Time: j.Hours,
+		Text: text,
+		Mode: gamedata.ModeJump,
+		OnStarted: func() {
+			player.Fuel -= j.FuelCost
+			player.Transit = gamedata.NewTransit(player, dst, j, hyperjump)
+		},
+		OnResolved: r.completeJump,
+	}
+}
+
+func (r *Runner) continueJumpChoice(text string) Choice {
+	return Choice{
+		Time:       r.world.Player.Transit.HoursLeft,
+		Text:       text,
+		Mode:       gamedata.ModeJump,
+		OnResolved: r.completeJump,
+	}
+}
+
+func (r *Runner) completeJump() gamedata.Mode {
+	player := r.world.Player
+	t := player.Transit
+	player.Transit = nil
+	player.Planet = t.Dst
+	player.CustomsPending = true
+	if t.Hyperjump {
+		player.TrainCrew(gamedata.CrewNavigator, 2)
+	} else {
+		player.TrainCrew(gamedata.CrewNavigator, 1)
+	}
+	return gamedata.ModeJustEntered
+}
+
+// CanLeave reports whether the player can switch to another screen,
+// like the vessel loadout, without losing the current choices context.
+func (r *Runner) CanLeave() bool {
+	return r.canLeave
+}
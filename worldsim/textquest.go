@@ -0,0 +1,182 @@
+package worldsim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quasilyte/ge/xslices"
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+func (r *Runner) pickTextQuest() *gamedata.TextQuestDesign {
+	picker := gmath.NewRandPicker[*gamedata.TextQuestDesign](r.scene.Rand())
+	for _, q := range gamedata.TextQuests {
+		if xslices.Contains(r.world.CompletedTextQuests, q.ID) {
+			continue
+		}
+		if !r.eventConditionsMet(&q.Conditions) {
+			continue
+		}
+		picker.AddOption(q, q.Weight)
+	}
+	if picker.IsEmpty() {
+		return nil
+	}
+	return picker.Pick()
+}
+
+func (r *Runner) barChoices() string {
+	q := r.pickTextQuest()
+	if q == nil || !r.scene.Rand().Chance(0.7) {
+		r.choices = append(r.choices, Choice{
+			Text: "Leave the bar",
+			OnResolved: func() gamedata.Mode {
+				return gamedata.ModeDocked
+			},
+		})
+		return "The bar is quiet today. Nobody seems to need a ranger's help."
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Accept",
+		Time: 1,
+		OnResolved: func() gamedata.Mode {
+			r.world.TextQuest = gamedata.NewTextQuestState(q)
+			return gamedata.ModeDocked
+		},
+	})
+	r.choices = append(r.choices, Choice{
+		Text: "Decline",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeDocked
+		},
+	})
+
+	lines := make([]string, 0, 3)
+	lines = append(lines, cfmt("<y>%s</>", q.Title))
+	lines = append(lines, "")
+	lines = append(lines, r.formatTextQuestText(q.Description, nil))
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) formatTextQuestText(s string, state *gamedata.TextQuestState) string {
+	if state != nil {
+		pairs := make([]string, 0, len(state.Params)*2)
+		for name, v := range state.Params {
+			pairs = append(pairs, "{"+name+"}", strconv.Itoa(v))
+		}
+		s = strings.NewReplacer(pairs...).Replace(s)
+	}
+	return r.formatEventText(s)
+}
+
+func (r *Runner) textQuestChoices() string {
+	state := r.world.TextQuest
+	loc := state.Location
+
+	lines := make([]string, 0, 8)
+	lines = append(lines, r.formatTextQuestText(loc.Text, state))
+	visibleParams := false
+	for _, p := range state.Quest.Params {
+		if !p.Visible {
+			continue
+		}
+		if !visibleParams {
+			lines = append(lines, "")
+			visibleParams = true
+		}
+		lines = append(lines, cfmt("* %s: <y>%d</>", p.Label, state.Params[p.Name]))
+	}
+
+	// Keep one slot for the give up option.
+	for _, t := range loc.Transitions {
+		if len(r.choices) >= MaxChoices-1 {
+			break
+		}
+		if !textQuestConditionsMet(t.Conditions, state.Params) {
+			continue
+		}
+		t := t
+		r.choices = append(r.choices, Choice{
+			Text: t.Text,
+			Time: t.Time,
+			OnResolved: func() gamedata.Mode {
+				r.applyTextQuestTransition(state, t)
+				return gamedata.ModeDocked
+			},
+		})
+	}
+	r.choices = append(r.choices, Choice{
+		Text: "Give up",
+		OnResolved: func() gamedata.Mode {
+			r.finishTextQuest(state, "lose", "You gave up. Some things are better left alone.")
+			return gamedata.ModeDocked
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func textQuestConditionsMet(conditions []gamedata.TextQuestCondition, params map[string]int) bool {
+	for i := range conditions {
+		if !conditions[i].Check(params) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runner) applyTextQuestTransition(state *gamedata.TextQuestState, t *gamedata.TextQuestTransition) {
+	for _, e := range t.Effects {
+		v := r.randIntRange(e.Value)
+		switch e.Op {
+		case "add":
+			state.Params[e.Param] += v
+		case "set":
+			state.Params[e.Param] = v
+		}
+	}
+	state.Location = state.Quest.FindLocation(t.To)
+
+	if state.Location.Ending != "" {
+		r.finishTextQuest(state, state.Location.Ending, state.Location.Text)
+		return
+	}
+	for _, th := range state.Quest.Thresholds {
+		if th.Check(state.Params) {
+			r.finishTextQuest(state, th.Outcome, th.Text)
+			return
+		}
+	}
+}
+
+func (r *Runner) finishTextQuest(state *gamedata.TextQuestState, outcome, text string) {
+	r.world.TextQuest = nil
+	r.world.CompletedTextQuests = append(r.world.CompletedTextQuests, state.Quest.ID)
+
+	effects := &state.Quest.Penalty
+	logText := fmt.Sprintf("Failed the %q quest", state.Quest.Title)
+	if outcome == "win" {
+		effects = &state.Quest.Reward
+		logText = fmt.Sprintf("Completed the %q quest", state.Quest.Title)
+	}
+	r.world.PushEvent(gamedata.WorldEvent{
+		Category: gamedata.EventQuest,
+		Planet:   r.world.Player.Planet,
+		Text:     logText,
+	})
+
+	lines := make([]string, 0, 8)
+	lines = append(lines, r.formatTextQuestText(text, state))
+	effectLines := r.applyEventEffects(effects)
+	if len(effectLines) != 0 {
+		lines = append(lines, "")
+		lines = append(lines, effectLines...)
+	}
+	r.eventInfo = eventInfo{
+		kind: eventMessage,
+		text: strings.Join(lines, "\n"),
+	}
+}
@@ -0,0 +1,233 @@
+package worldsim
+
+import (
+	"strings"
+
+	"github.com/quasilyte/gmath"
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+// processTransitEvents rolls an in-transit event for the ongoing jump.
+// There can be at most one event per jump.
+func (r *Runner) processTransitEvents() bool {
+	player := r.world.Player
+	t := player.Transit
+	if t.Interrupted {
+		return false
+	}
+
+	// The event chance is given for the whole jump; spread it over the jump hours.
+	chance := t.EventChance() * r.world.Difficulty.EncounterMultiplier / float64(gmath.ClampMin(t.Jump.Hours, 1))
+	if !r.scene.Rand().Chance(chance) {
+		return false
+	}
+	t.Interrupted = true
+
+	// A hostile squad moving along the same lane is the most likely thing to meet.
+	if squad := r.findLaneSquad(t); squad != nil {
+		enemy := squad.Vessels[len(squad.Vessels)-1]
+		squad.Vessels = squad.Vessels[:len(squad.Vessels)-1]
+		enemy.OriginSquad = squad
+		gamedata.InitVesselDesign(r.scene.Rand(), r.world, enemy)
+		r.eventInfo = eventInfo{
+			kind:    eventBattleInterrupt,
+			enemy:   enemy,
+			transit: true,
+			text:    cfmt("Your jump lane is crossed by a <r>%s</> squad heading to <p>%s</>.", squad.Faction.Name(), squad.Dst.Info.Name),
+		}
+		return true
+	}
+
+	picker := gmath.NewRandPicker[eventKind](r.scene.Rand())
+	if r.world.Config.Pirates {
+		pirateWeight := 0.2
+		if t.Hostile {
+			// Pirates prefer the lanes that are not patrolled by the player faction.
+			pirateWeight = 0.35
+		}
+		picker.AddOption(eventBattleInterrupt, pirateWeight)
+	}
+	picker.AddOption(eventTransitDistress, 0.25)
+	picker.AddOption(eventTransitMalfunction, 0.25)
+	picker.AddOption(eventTransitAnomaly, 0.25)
+	kind := picker.Pick()
+	if kind == eventBattleInterrupt {
+		r.eventInfo = eventInfo{
+			kind:    eventBattleInterrupt,
+			enemy:   r.makePirate(),
+			transit: true,
+			text:    "Your vessel was pulled out of the jump by an interdiction field.",
+		}
+		return true
+	}
+	r.eventInfo = eventInfo{kind: kind}
+	return true
+}
+
+func (r *Runner) findLaneSquad(t *gamedata.Transit) *gamedata.Squad {
+	if t.Hyperjump {
+		return nil
+	}
+	player := r.world.Player
+	for _, squad := range r.world.Squads {
+		if squad.Faction == player.Faction || len(squad.Vessels) == 0 {
+			continue
+		}
+		sameLane := (squad.Src == t.Src && squad.Dst == t.Dst) ||
+			(squad.Src == t.Dst && squad.Dst == t.Src)
+		if sameLane {
+			return squad
+		}
+	}
+	return nil
+}
+
+// transitChoices is a screen that is shown after an in-transit event is resolved.
+func (r *Runner) transitChoices() string {
+	player := r.world.Player
+	t := player.Transit
+
+	lines := make([]string, 0, 4)
+	lines = append(lines, cfmt("In transit from <p>%s</> to <p>%s</>.", t.Src.Info.Name, t.Dst.Info.Name))
+	lines = append(lines, cfmt("<y>%d</> hours left until the arrival.", t.HoursLeft))
+
+	r.choices = append(r.choices, r.continueJumpChoice("Continue the jump to "+t.Dst.Info.Name))
+
+	// Going back takes as much time as it took to get here.
+	// The spent fuel is lost.
+	hoursBack := gmath.ClampMin(t.Jump.Hours-t.HoursLeft, 1)
+	r.choices = append(r.choices, Choice{
+		Time: hoursBack,
+		Text: "Return to " + t.Src.Info.Name,
+		Mode: gamedata.ModeJump,
+		OnStarted: func() {
+			player.Transit = nil
+		},
+		OnResolved: func() gamedata.Mode {
+			player.CustomsPending = true
+			return gamedata.ModeJustEntered
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) transitDistressChoices() string {
+	player := r.world.Player
+
+	r.choices = append(r.choices, Choice{
+		Time: 3,
+		Text: "Answer the signal",
+		Mode: gamedata.ModeInTransit,
+		OnResolved: func() gamedata.Mode {
+			roll := r.scene.Rand().Float()
+			switch {
+			case roll < 0.25 && r.world.Config.Pirates:
+				r.eventInfo = eventInfo{
+					kind:    eventBattleInterrupt,
+					enemy:   r.makePirate(),
+					transit: true,
+					text:    "It was a trap! The wreck turns out to be a disguised pirate vessel.",
+				}
+			case roll < 0.45:
+				fuel := gmath.ClampMax(r.scene.Rand().IntRange(5, 15), player.MaxFuel-player.Fuel)
+				player.Fuel += fuel
+				r.eventInfo = eventInfo{
+					kind: eventMessage,
+					text: cfmt("There are no survivors, but you managed to salvage <y>%d</> fuel units from the wreck.", fuel),
+				}
+			default:
+				credits := r.scene.Rand().IntRange(30, 90)
+				r.world.AddCredits(gamedata.CreditsEvent, credits)
+				player.Reputation = gmath.ClampMax(player.Reputation+1, gamedata.MaxReputation)
+				r.eventInfo = eventInfo{
+					kind: eventMessage,
+					text: cfmt("You rescued the crew of a damaged transport.\n\nThe grateful survivors paid you <y>%d</> credits.\nReputation: <g>+1</>", credits),
+				}
+			}
+			return gamedata.ModeInTransit
+		},
+	})
+	r.choices = append(r.choices, Choice{
+		Text: "Ignore the signal",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeInTransit
+		},
+	})
+
+	return "Your sensors pick up a distress signal coming from somewhere near the jump lane."
+}
+
+func (r *Runner) transitMalfunctionChoices() string {
+	player := r.world.Player
+	t := player.Transit
+
+	// A skilled navigator can reduce the delay.
+	delay := gmath.ClampMin(r.scene.Rand().IntRange(3, 8)-player.CrewSkill(gamedata.CrewNavigator), 1)
+	damage := 0.0
+	if r.scene.Rand().Chance(0.4) {
+		damage = r.scene.Rand().FloatRange(0.03, 0.1)
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Continue",
+		OnResolved: func() gamedata.Mode {
+			t.HoursLeft += delay
+			player.VesselHP = gmath.ClampMin(player.VesselHP-damage, 0.05)
+			return gamedata.ModeInTransit
+		},
+	})
+
+	lines := make([]string, 0, 4)
+	lines = append(lines, "The jump drive malfunctions and throws your vessel out of the jump lane.")
+	lines = append(lines, "")
+	lines = append(lines, cfmt("Recalibrating the drive will delay the arrival by <r>%d</> hours.", delay))
+	if damage != 0 {
+		lines = append(lines, formatEffectDelta("Vessel structure (%)", -int(damage*100)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) transitAnomalyChoices() string {
+	player := r.world.Player
+
+	r.choices = append(r.choices, Choice{
+		Time: 2,
+		Text: "Investigate the anomaly",
+		Mode: gamedata.ModeInTransit,
+		OnResolved: func() gamedata.Mode {
+			lines := make([]string, 0, 4)
+			lines = append(lines, "You approached the anomaly and collected everything of value.", "")
+			switch r.scene.Rand().IntRange(0, 2) {
+			case 0:
+				credits := r.scene.Rand().IntRange(40, 120)
+				r.world.AddCredits(gamedata.CreditsEvent, credits)
+				lines = append(lines, formatEffectDelta("Credits", credits))
+			case 1:
+				lines = append(lines, formatEffectDelta("Cargo", player.LoadCargo(r.scene.Rand().IntRange(5, 15))))
+			default:
+				fuel := gmath.ClampMax(r.scene.Rand().IntRange(10, 20), player.MaxFuel-player.Fuel)
+				player.Fuel += fuel
+				lines = append(lines, formatEffectDelta("Fuel", fuel))
+			}
+			if r.scene.Rand().Chance(0.3) {
+				damage := r.scene.Rand().FloatRange(0.05, 0.15)
+				player.VesselHP = gmath.ClampMin(player.VesselHP-damage, 0.05)
+				lines = append(lines, formatEffectDelta("Vessel structure (%)", -int(damage*100)))
+			}
+			r.eventInfo = eventInfo{
+				kind: eventMessage,
+				text: strings.Join(lines, "\n"),
+			}
+			return gamedata.ModeInTransit
+		},
+	})
+	r.choices = append(r.choices, Choice{
+		Text: "Ignore the anomaly",
+		OnResolved: func() gamedata.Mode {
+			return gamedata.ModeInTransit
+		},
+	})
+
+	return "A cosmic anomaly is detected close to the jump lane. Its gravity field holds some space debris."
+}
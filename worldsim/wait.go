@@ -0,0 +1,137 @@
+package worldsim
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/quasilyte/vcgj7-game/gamedata"
+)
+
+// waitInfo describes the things that can interrupt the waiting.
+// The encounters interrupt it without any extra checks.
+type waitInfo struct {
+	// squad is a squad the player is waiting for; can be nil.
+	squad *gamedata.Squad
+
+	quest       *gamedata.Quest
+	questActive bool
+
+	numEvents int
+}
+
+func (r *Runner) waitChoices() string {
+	player := r.world.Player
+	planet := player.Planet
+	mode := player.Mode
+
+	lines := make([]string, 0, 4)
+	lines = append(lines, "How long do you want to hold the position?")
+	lines = append(lines, "")
+	lines = append(lines, "The waiting is interrupted by encounters, planet captures, crew changes and quest updates.")
+
+	// Pick the squad that arrives first.
+	var squad *gamedata.Squad
+	squadHours := 0
+	for _, s := range r.world.Squads {
+		if s.Dst != planet {
+			continue
+		}
+		h := int(math.Ceil(s.Dist / s.Speed))
+		if squad == nil || h < squadHours {
+			squad = s
+			squadHours = h
+		}
+	}
+	if squad != nil {
+		r.choices = append(r.choices, r.newWaitChoice(
+			fmt.Sprintf("Until the %s squad arrives (%d hours)", squad.Faction.Name(), squadHours),
+			squadHours+1, squad))
+	}
+
+	salaryHours := 24 - r.world.GameTime%24
+	r.choices = append(r.choices, r.newWaitChoice(fmt.Sprintf("Until the next salary (%d hours)", salaryHours), salaryHours, nil))
+	for _, h := range []int{4, 12, 24} {
+		r.choices = append(r.choices, r.newWaitChoice(fmt.Sprintf("Wait for %d hours", h), h, nil))
+	}
+
+	r.choices = append(r.choices, Choice{
+		Text: "Cancel",
+		OnResolved: func() gamedata.Mode {
+			return mode
+		},
+	})
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Runner) newWaitChoice(text string, hours int, squad *gamedata.Squad) Choice {
+	player := r.world.Player
+	mode := player.Mode
+	return Choice{
+		Time: hours,
+		Text: text,
+		OnStarted: func() {
+			r.wait = &waitInfo{
+				squad:     squad,
+				quest:     r.world.CurrentQuest,
+				numEvents: len(r.world.EventLog),
+			}
+			if r.wait.quest != nil {
+				r.wait.questActive = r.wait.quest.Active
+			}
+		},
+		OnResolved: func() gamedata.Mode {
+			r.eventInfo = eventInfo{
+				kind: eventMessage,
+				text: fmt.Sprintf("You held the position for %d hours. Nothing of interest happened.", hours),
+			}
+			return mode
+		},
+	}
+}
+
+// checkWaitInterrupt returns a non-empty interruption reason
+// if the waiting should be stopped.
+func (r *Runner) checkWaitInterrupt(w *waitInfo) string {
+	player := r.world.Player
+
+	if w.squad != nil {
+		arrived := true
+		for _, s := range r.world.Squads {
+			if s == w.squad {
+				arrived = false
+				break
+			}
+		}
+		if arrived {
+			return cfmt("The <p>%s</> squad has arrived at <p>%s</>.", w.squad.Faction.Name(), w.squad.Dst.Info.Name)
+		}
+	}
+
+	for _, e := range r.world.EventLog[w.numEvents:] {
+		if e.Category == gamedata.EventCrew {
+			return "Your waiting was interrupted:\n\n" + e.Text + "."
+		}
+		if e.Category != gamedata.EventCapture && e.Category != gamedata.EventLoss {
+			continue
+		}
+		if e.Planet != nil && e.Planet.System != player.Planet.System {
+			continue
+		}
+		return "Your waiting was interrupted by the news:\n\n" + e.Text + "."
+	}
+	w.numEvents = len(r.world.EventLog)
+
+	quest := r.world.CurrentQuest
+	switch {
+	case w.quest != nil && w.questActive && quest != w.quest:
+		return "Your waiting was interrupted: the delivery quest has failed."
+	case w.quest != nil && !w.questActive && quest != w.quest:
+		return "Your waiting was interrupted: the delivery quest offer has expired."
+	case w.quest == nil && quest != nil:
+		return cfmt("Your waiting was interrupted: a delivery quest is available at <p>%s</>.", quest.Giver.Info.Name)
+	}
+
+	return ""
+}